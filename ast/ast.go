@@ -0,0 +1,57 @@
+// Package ast is a statically typed form of the trees produced by the parser.
+// Every ESTree node type has a struct implementing Node, whose fields are
+// tagged with the keys of the map form:
+//
+//	program, err := ast.Parse(parser.New(tokenizer.New("a = 1;")))
+//	statement := program.Body[0].(*ast.ExpressionStatement)
+//	assignment := statement.Expression.(*ast.AssignmentExpression)
+//
+// FromMap and ToMap convert between the typed and the map form, and nodes
+// marshal to the same JSON as parser.Node.
+//
+// The grammar still builds the map form: the typed tree is converted from it,
+// so it is safer to use but costs more to build than parser.Node, not less.
+package ast
+
+import "github.com/0xvesion/go-js-parser/parser"
+
+//go:generate go run gen.go
+
+// Node is a typed AST node.
+type Node interface {
+	Type() parser.Type
+	Start() int
+	End() int
+}
+
+// Span holds the fields shared by every node: its range and, when the parser
+// adds locations, its 'loc'.
+type Span struct {
+	StartOffset int                    `estree:"start"`
+	EndOffset   int                    `estree:"end"`
+	Loc         *parser.SourceLocation `estree:"loc,omitempty"`
+}
+
+func (s *Span) Start() int {
+	return s.StartOffset
+}
+
+func (s *Span) End() int {
+	return s.EndOffset
+}
+
+// Parse parses a program and converts it to its typed form. In tolerant mode,
+// the partial program is returned along with the errors.
+func Parse(p parser.Parser) (*Program, error) {
+	n, err := p.Parse()
+	if n == nil {
+		return nil, err
+	}
+
+	program, convertErr := FromMap(n)
+	if convertErr != nil {
+		return nil, convertErr
+	}
+
+	return program.(*Program), err
+}
@@ -0,0 +1,80 @@
+package ast_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/ast"
+	"github.com/0xvesion/go-js-parser/internal/testsource"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+// sources add the trees only the typed form has to cover to the shared ones.
+var sources = append([]testsource.Source{
+	{Src: `""; function f() { ''; return; }`},
+	{Src: `let a = ; b = 1;`, Options: parser.Options{Tolerant: true}},
+}, testsource.Sources...)
+
+func toJSON(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, s := range sources {
+		n := testsource.Parse(t, s.Src, s.Options)
+
+		typed, err := ast.FromMap(n)
+		if err != nil {
+			t.Errorf("%s: %v", s.Src, err)
+			continue
+		}
+
+		want := toJSON(t, n)
+		if got := toJSON(t, typed); got != want {
+			t.Errorf("%s: JSON of the typed node differs.\nwant: %s\ngot:  %s", s.Src, want, got)
+		}
+		if got := toJSON(t, ast.ToMap(typed)); got != want {
+			t.Errorf("%s: JSON of the converted map differs.\nwant: %s\ngot:  %s", s.Src, want, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	program, err := ast.Parse(parser.New(tokenizer.New(`a = 1;`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assignment := program.Body[0].(*ast.ExpressionStatement).Expression.(*ast.AssignmentExpression)
+	if assignment.Left.(*ast.Identifier).Name != "a" || assignment.Right.(*ast.Literal).Raw != "1" {
+		t.Errorf("Unexpected assignment: %+v", assignment)
+	}
+	if assignment.Start() != 0 || assignment.End() != 5 {
+		t.Errorf("Unexpected range: %d-%d", assignment.Start(), assignment.End())
+	}
+
+	if _, err := ast.Parse(parser.New(tokenizer.New(`a = ;`))); err == nil {
+		t.Error("Expected a syntax error")
+	}
+}
+
+func TestFromMapErrors(t *testing.T) {
+	for _, n := range []parser.Node{
+		{"type": "Identifier", "start": 0, "end": 1, "name": "a"},
+		{"type": parser.Type("Unknown"), "start": 0, "end": 1},
+		{"type": parser.Type(parser.Identifier), "start": 0, "end": 1, "nam": "a"},
+		{"type": parser.Type(parser.Identifier), "start": 0, "end": 1, "name": 1},
+		{"type": parser.Type(parser.BlockStatement), "start": 0, "end": 1, "body": []interface{}{}},
+		{"type": parser.Type(parser.ReturnStatement), "start": 0, "end": 1, "argument": parser.Node{"type": "x"}},
+	} {
+		if _, err := ast.FromMap(n); err == nil {
+			t.Errorf("Expected an error converting %v", n)
+		}
+	}
+}
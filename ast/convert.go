@@ -0,0 +1,176 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/parser"
+)
+
+// field is a struct field holding the value of a key of the map form.
+type field struct {
+	index     []int
+	omitempty bool
+}
+
+// structs are the struct types of the node types, with their fields by key.
+var structs = map[parser.Type]reflect.Type{}
+var fields = map[reflect.Type]map[string]field{}
+
+var (
+	nodeType     = reflect.TypeOf((*Node)(nil)).Elem()
+	nodeListType = reflect.TypeOf([]Node(nil))
+	locationType = reflect.TypeOf((*parser.SourceLocation)(nil))
+	stringType   = reflect.TypeOf((*string)(nil))
+)
+
+func init() {
+	for _, n := range nodes {
+		t := reflect.TypeOf(n).Elem()
+		structs[n.Type()] = t
+		fields[t] = map[string]field{}
+
+		for _, f := range reflect.VisibleFields(t) {
+			tag, ok := f.Tag.Lookup("estree")
+			if !ok {
+				continue
+			}
+
+			key, options, _ := strings.Cut(tag, ",")
+			fields[t][key] = field{f.Index, options == "omitempty"}
+		}
+	}
+}
+
+// FromMap converts a node and its children to their typed form. It fails on
+// unknown node types, unknown keys and values of the wrong type.
+func FromMap(n parser.Node) (Node, error) {
+	t, ok := n["type"].(parser.Type)
+	if !ok {
+		return nil, fmt.Errorf("node without a type: %v", n["type"])
+	}
+
+	structType, ok := structs[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown node type: %s", t)
+	}
+
+	node := reflect.New(structType)
+	for key, value := range n {
+		if key == "type" {
+			continue
+		}
+
+		f, ok := fields[structType][key]
+		if !ok {
+			return nil, fmt.Errorf("%s has no field '%s'", t, key)
+		}
+
+		if err := setField(node.Elem().FieldByIndex(f.index), value); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t, key, err)
+		}
+	}
+
+	return node.Interface().(Node), nil
+}
+
+func setField(v reflect.Value, value interface{}) error {
+	switch v.Type() {
+	case nodeType:
+		child, ok := value.(parser.Node)
+		if value == nil || ok && child == nil {
+			return nil
+		} else if !ok {
+			return fmt.Errorf("want a node, got %T", value)
+		}
+
+		typed, err := FromMap(child)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(typed))
+	case nodeListType:
+		children, ok := value.([]parser.Node)
+		if !ok {
+			return fmt.Errorf("want a node list, got %T", value)
+		}
+
+		list := make([]Node, len(children))
+		for i, child := range children {
+			typed, err := FromMap(child)
+			if err != nil {
+				return err
+			}
+			list[i] = typed
+		}
+		v.Set(reflect.ValueOf(list))
+	case locationType:
+		loc, ok := value.(parser.SourceLocation)
+		if !ok {
+			return fmt.Errorf("want a location, got %T", value)
+		}
+		v.Set(reflect.ValueOf(&loc))
+	case stringType:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("want a string, got %T", value)
+		}
+		v.Set(reflect.ValueOf(&s))
+	default:
+		if value == nil {
+			return nil
+		}
+
+		// Kinds are compared too, as reflect converts ints to strings.
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != v.Kind() && v.Kind() != reflect.Interface || !rv.Type().ConvertibleTo(v.Type()) {
+			return fmt.Errorf("want %s, got %T", v.Type(), value)
+		}
+		v.Set(rv.Convert(v.Type()))
+	}
+
+	return nil
+}
+
+// ToMap converts a typed node and its children to the map form. Optional
+// fields are left out when empty, like the parser does.
+func ToMap(n Node) parser.Node {
+	v := reflect.ValueOf(n).Elem()
+	m := parser.Node{"type": n.Type()}
+
+	for key, f := range fields[v.Type()] {
+		value := v.FieldByIndex(f.index)
+		if f.omitempty && value.IsZero() {
+			continue
+		}
+
+		switch value.Type() {
+		case nodeType:
+			if value.IsNil() {
+				m[key] = parser.Node(nil)
+			} else {
+				m[key] = ToMap(value.Interface().(Node))
+			}
+		case nodeListType:
+			list := make([]parser.Node, value.Len())
+			for i := range list {
+				list[i] = ToMap(value.Index(i).Interface().(Node))
+			}
+			m[key] = list
+		case locationType:
+			m[key] = *value.Interface().(*parser.SourceLocation)
+		case stringType:
+			m[key] = *value.Interface().(*string)
+		default:
+			m[key] = value.Interface()
+		}
+	}
+
+	return m
+}
+
+func marshalJSON(n Node) ([]byte, error) {
+	return json.Marshal(ToMap(n))
+}
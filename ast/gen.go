@@ -0,0 +1,182 @@
+//go:build ignore
+
+// gen generates nodes.go, the node structs, from the keys and kinds of the
+// fields of every node type. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+// A field is written key:kind, and is optional when the kind ends with '?'.
+// The kinds are N for a node, L for a list of nodes, S for a string, P for a
+// string which may be missing even when empty, B for a bool, K for the kind
+// of a method and V for the value of a literal.
+const (
+	function          = "id:N expression:B async:B generator:B params:L body:N typeParameters:N? returnType:N?"
+	class             = "id:N superClass:N body:N decorators:L? typeParameters:N? superTypeArguments:N? implements:L? abstract:B?"
+	methodModifiers   = "decorators:L? optional:B? accessibility:S? readonly:B? override:B? declare:B?"
+	propertyModifiers = "decorators:L? typeAnnotation:N? optional:B? definite:B? accessibility:S? readonly:B? override:B? declare:B?"
+)
+
+// nodes mirror the constructors of parser/model.go.
+var nodes = []struct {
+	name   string
+	fields string
+}{
+	{"Program", "body:L sourceType:S"},
+	{"Literal", "value:V raw:S"},
+	{"ExpressionStatement", "expression:N directive:P?"},
+	{"BlockStatement", "body:L"},
+	{"EmptyStatement", ""},
+	{"BinaryExpression", "operator:S left:N right:N"},
+	{"AssignmentExpression", "operator:S left:N right:N"},
+	{"Identifier", "name:S optional:B? typeAnnotation:N?"},
+	{"VariableDeclaration", "kind:S declarations:L"},
+	{"VariableDeclarator", "id:N init:N definite:B?"},
+	{"IfStatement", "test:N consequent:N alternate:N"},
+	{"LogicalExpression", "operator:S left:N right:N"},
+	{"UnaryExpression", "operator:S prefix:B argument:N"},
+	{"WhileStatement", "test:N body:N"},
+	{"ForStatement", "init:N test:N update:N body:N"},
+	{"DoWhileStatement", "body:N test:N"},
+	{"FunctionDeclaration", function},
+	{"ReturnStatement", "argument:N"},
+	{"MemberExpression", "object:N property:N computed:B optional:B"},
+	{"CallExpression", "callee:N arguments:L optional:B typeArguments:N?"},
+	{"ClassDeclaration", class},
+	{"ClassBody", "body:L"},
+	{"PropertyDefinition", "key:N value:N computed:B static:B " + propertyModifiers},
+	{"MethodDefinition", "key:N value:N kind:K computed:B static:B " + methodModifiers},
+	{"FunctionExpression", function},
+	{"Super", ""},
+	{"ThisExpression", ""},
+	{"ClassExpression", class},
+	{"AccessorProperty", "key:N value:N computed:B static:B " + propertyModifiers},
+	{"Decorator", "expression:N"},
+	{"ErrorNode", ""},
+	{"JSXElement", "openingElement:N closingElement:N children:L"},
+	{"JSXOpeningElement", "name:N attributes:L selfClosing:B"},
+	{"JSXClosingElement", "name:N"},
+	{"JSXFragment", "openingFragment:N closingFragment:N children:L"},
+	{"JSXOpeningFragment", "attributes:L selfClosing:B"},
+	{"JSXClosingFragment", ""},
+	{"JSXAttribute", "name:N value:N"},
+	{"JSXSpreadAttribute", "argument:N"},
+	{"JSXExpressionContainer", "expression:N"},
+	{"JSXEmptyExpression", ""},
+	{"JSXSpreadChild", "expression:N"},
+	{"JSXText", "value:S raw:S"},
+	{"JSXIdentifier", "name:S"},
+	{"JSXMemberExpression", "object:N property:N"},
+	{"JSXNamespacedName", "namespace:N name:N"},
+	{"TSTypeAnnotation", "typeAnnotation:N"},
+	{"TSAnyKeyword", ""},
+	{"TSUnknownKeyword", ""},
+	{"TSNumberKeyword", ""},
+	{"TSObjectKeyword", ""},
+	{"TSBooleanKeyword", ""},
+	{"TSBigIntKeyword", ""},
+	{"TSStringKeyword", ""},
+	{"TSSymbolKeyword", ""},
+	{"TSVoidKeyword", ""},
+	{"TSUndefinedKeyword", ""},
+	{"TSNullKeyword", ""},
+	{"TSNeverKeyword", ""},
+	{"TSThisType", ""},
+	{"TSTypeReference", "typeName:N typeArguments:N?"},
+	{"TSQualifiedName", "left:N right:N"},
+	{"TSArrayType", "elementType:N"},
+	{"TSIndexedAccessType", "objectType:N indexType:N"},
+	{"TSUnionType", "types:L"},
+	{"TSIntersectionType", "types:L"},
+	{"TSTypeOperator", "operator:S typeAnnotation:N"},
+	{"TSLiteralType", "literal:N"},
+	{"TSTupleType", "elementTypes:L"},
+	{"TSTypeLiteral", "members:L"},
+	{"TSPropertySignature", "key:N computed:B static:B optional:B readonly:B typeAnnotation:N?"},
+	{"TSMethodSignature", "key:N kind:K computed:B static:B optional:B readonly:B params:L typeParameters:N? returnType:N?"},
+	{"TSIndexSignature", "parameters:L static:B readonly:B typeAnnotation:N?"},
+	{"TSFunctionType", "params:L returnType:N typeParameters:N?"},
+	{"TSTypeParameterDeclaration", "params:L"},
+	{"TSTypeParameter", "name:N in:B out:B const:B constraint:N? default:N?"},
+	{"TSTypeParameterInstantiation", "params:L"},
+	{"TSInterfaceDeclaration", "id:N extends:L body:N declare:B typeParameters:N?"},
+	{"TSInterfaceBody", "body:L"},
+	{"TSInterfaceHeritage", "expression:N typeArguments:N?"},
+	{"TSTypeAliasDeclaration", "id:N typeAnnotation:N declare:B typeParameters:N?"},
+	{"TSEnumDeclaration", "id:N members:L const:B declare:B"},
+	{"TSEnumMember", "id:N computed:B initializer:N?"},
+	{"TSAsExpression", "expression:N typeAnnotation:N"},
+	{"TSSatisfiesExpression", "expression:N typeAnnotation:N"},
+	{"TSNonNullExpression", "expression:N"},
+	{"TSParameterProperty", "parameter:N static:B readonly:B override:B accessibility:S?"},
+	{"TSClassImplements", "expression:N typeArguments:N?"},
+	{"TSAbstractMethodDefinition", "key:N value:N kind:K computed:B static:B " + methodModifiers},
+	{"TSAbstractPropertyDefinition", "key:N value:N computed:B static:B " + propertyModifiers},
+	{"TSEmptyBodyFunctionExpression", "id:N params:L body:N expression:B async:B generator:B declare:B typeParameters:N? returnType:N?"},
+}
+
+var kinds = map[string]string{
+	"N": "Node",
+	"L": "[]Node",
+	"S": "string",
+	"P": "*string",
+	"B": "bool",
+	"K": "parser.MethodDefinitionKind",
+	"V": "interface{}",
+}
+
+func main() {
+	out := &bytes.Buffer{}
+	fmt.Fprint(out, `// Code generated by gen.go; DO NOT EDIT.
+
+package ast
+
+import "github.com/0xvesion/go-js-parser/parser"
+
+// The nodes mirror the constructors of parser/model.go. Fields tagged
+// omitempty are optional and only present when the syntax is used.
+`)
+
+	for _, n := range nodes {
+		fmt.Fprintf(out, "\ntype %s struct {\n\tSpan\n", n.name)
+		for _, f := range strings.Fields(n.fields) {
+			key, kind, _ := strings.Cut(f, ":")
+			tag := key
+			if strings.HasSuffix(kind, "?") {
+				kind, tag = strings.TrimSuffix(kind, "?"), key+",omitempty"
+			}
+			fmt.Fprintf(out, "\t%s %s `estree:\"%s\"`\n", strings.ToUpper(key[:1])+key[1:], kinds[kind], tag)
+		}
+		fmt.Fprint(out, "}\n\n")
+
+		// The type of Super nodes is named after the expression.
+		t := n.name
+		if t == "Super" {
+			t = "SuperExpression"
+		}
+		fmt.Fprintf(out, "func (n *%s) Type() parser.Type { return parser.%s }\n", n.name, t)
+		fmt.Fprintf(out, "func (n *%s) MarshalJSON() ([]byte, error) { return marshalJSON(n) }\n", n.name)
+	}
+
+	fmt.Fprint(out, "\n// nodes are the zero values of every node type, indexed by FromMap.\nvar nodes = []Node{\n")
+	for _, n := range nodes {
+		fmt.Fprintf(out, "\t&%s{},\n", n.name)
+	}
+	fmt.Fprint(out, "}\n")
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("nodes.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,484 @@
+// Package builder creates nodes for code generation. Unlike the constructors
+// of the parser package, the builders take no positions, and they check that
+// every child is of an allowed kind:
+//
+//	import b "github.com/0xvesion/go-js-parser/builder"
+//
+//	log := b.Call(b.Member(b.Ident("console"), "log"), b.Str("hi"))
+//
+// Built nodes start and end at 0. The builders panic with a *BuildError for
+// invalid children, which Try turns into an error.
+package builder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/parser"
+)
+
+// BuildError is the value the builders panic with for invalid arguments.
+type BuildError struct {
+	// Builder is the name of the builder, such as "Binary".
+	Builder string
+	// Argument is the name of the invalid argument.
+	Argument string
+	Message  string
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("builder.%s: %s %s", e.Builder, e.Argument, e.Message)
+}
+
+// Try calls build and returns the *BuildError it panics with as an error.
+func Try(build func() parser.Node) (n parser.Node, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			buildErr, ok := r.(*BuildError)
+			if !ok {
+				panic(r)
+			}
+
+			n, err = nil, buildErr
+		}
+	}()
+
+	return build(), nil
+}
+
+var expressionTypes = map[parser.Type]bool{
+	parser.Literal:               true,
+	parser.Identifier:            true,
+	parser.BinaryExpression:      true,
+	parser.AssignmentExpression:  true,
+	parser.LogicalExpression:     true,
+	parser.UnaryExpression:       true,
+	parser.MemberExpression:      true,
+	parser.CallExpression:        true,
+	parser.FunctionExpression:    true,
+	parser.ClassExpression:       true,
+	parser.ThisExpression:        true,
+	parser.JSXElement:            true,
+	parser.JSXFragment:           true,
+	parser.TSAsExpression:        true,
+	parser.TSSatisfiesExpression: true,
+	parser.TSNonNullExpression:   true,
+}
+
+var statementTypes = map[parser.Type]bool{
+	parser.ExpressionStatement:    true,
+	parser.BlockStatement:         true,
+	parser.EmptyStatement:         true,
+	parser.VariableDeclaration:    true,
+	parser.IfStatement:            true,
+	parser.WhileStatement:         true,
+	parser.ForStatement:           true,
+	parser.DoWhileStatement:       true,
+	parser.FunctionDeclaration:    true,
+	parser.ReturnStatement:        true,
+	parser.ClassDeclaration:       true,
+	parser.TSInterfaceDeclaration: true,
+	parser.TSTypeAliasDeclaration: true,
+	parser.TSEnumDeclaration:      true,
+}
+
+// expression checks that an argument is an expression.
+func expression(builder string, argument string, n parser.Node) parser.Node {
+	if n == nil {
+		panic(&BuildError{builder, argument, "is missing"})
+	}
+	if !expressionTypes[n.Type()] {
+		panic(&BuildError{builder, argument, fmt.Sprintf("must be an expression, got %s", n.Type())})
+	}
+
+	return n
+}
+
+// optionalExpression checks that an argument is an expression or nil.
+func optionalExpression(builder string, argument string, n parser.Node) parser.Node {
+	if n == nil {
+		return nil
+	}
+
+	return expression(builder, argument, n)
+}
+
+// statement checks that an argument is a statement. Expressions are wrapped
+// in an ExpressionStatement.
+func statement(builder string, argument string, n parser.Node) parser.Node {
+	if n == nil {
+		panic(&BuildError{builder, argument, "is missing"})
+	}
+	if expressionTypes[n.Type()] {
+		return Expr(n)
+	}
+	if !statementTypes[n.Type()] {
+		panic(&BuildError{builder, argument, fmt.Sprintf("must be a statement, got %s", n.Type())})
+	}
+
+	return n
+}
+
+func statements(builder string, argument string, list []parser.Node) []parser.Node {
+	res := make([]parser.Node, len(list))
+	for i, n := range list {
+		res[i] = statement(builder, fmt.Sprintf("%s[%d]", argument, i), n)
+	}
+
+	return res
+}
+
+func operator(builder string, op string, allowed ...string) string {
+	for _, a := range allowed {
+		if op == a {
+			return op
+		}
+	}
+
+	panic(&BuildError{builder, "operator", fmt.Sprintf("must be one of %s, got '%s'", strings.Join(allowed, " "), op)})
+}
+
+func name(builder string, argument string, s string) string {
+	if !isIdentifierName(s) {
+		panic(&BuildError{builder, argument, fmt.Sprintf("must be an identifier, got %q", s)})
+	}
+
+	return s
+}
+
+// binding checks that a name can declare or reference a binding, which
+// reserved words can't. They can still name properties.
+func binding(builder string, argument string, s string) string {
+	if reservedWords[name(builder, argument, s)] {
+		panic(&BuildError{builder, argument, fmt.Sprintf("must not be a reserved word, got %q", s)})
+	}
+
+	return s
+}
+
+// reservedWords are the reserved words of JavaScript, including the ones
+// only reserved in strict code.
+var reservedWords = map[string]bool{}
+
+func init() {
+	for _, word := range []string{
+		"await", "break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete",
+		"do", "else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "import",
+		"in", "instanceof", "new", "null", "return", "super", "switch", "this", "throw", "true", "try",
+		"typeof", "var", "void", "while", "with", "yield", "implements", "interface", "let", "package",
+		"private", "protected", "public", "static",
+	} {
+		reservedWords[word] = true
+	}
+}
+
+func isIdentifierName(s string) bool {
+	for i, c := range s {
+		isLetter := c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+		if !isLetter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// Ident returns an Identifier, which can't be a reserved word.
+func Ident(s string) parser.Node {
+	return parser.NewIdentifier(0, 0, binding("Ident", "name", s))
+}
+
+// Str returns a string Literal.
+func Str(value string) parser.Node {
+	return parser.NewLiteral(0, 0, value, quote(value))
+}
+
+// quote returns a JavaScript string literal for a string.
+func quote(s string) string {
+	res := strings.Builder{}
+	res.WriteByte('"')
+
+	for _, c := range s {
+		switch c {
+		case '"', '\\':
+			res.WriteByte('\\')
+			res.WriteRune(c)
+		case '\n':
+			res.WriteString(`\n`)
+		case '\r':
+			res.WriteString(`\r`)
+		case '\t':
+			res.WriteString(`\t`)
+		case '\u2028', '\u2029':
+			fmt.Fprintf(&res, `\u%04x`, c)
+		default:
+			if c < 0x20 {
+				fmt.Fprintf(&res, `\x%02x`, c)
+			} else {
+				res.WriteRune(c)
+			}
+		}
+	}
+
+	res.WriteByte('"')
+
+	return res.String()
+}
+
+// Num returns a number Literal. Integers have an int value and are written
+// with their digits, like the ones the parser creates. Negative numbers
+// become a UnaryExpression.
+func Num(value float64) parser.Node {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		panic(&BuildError{"Num", "value", fmt.Sprintf("must be finite, got %v", value)})
+	}
+	if value < 0 || value == 0 && math.Signbit(value) {
+		return Unary("-", Num(-value))
+	}
+
+	if value == math.Trunc(value) && value < math.MaxInt {
+		return parser.NewLiteral(0, 0, int(value), strconv.Itoa(int(value)))
+	}
+
+	return parser.NewLiteral(0, 0, value, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// Bool returns a boolean Literal.
+func Bool(value bool) parser.Node {
+	return parser.NewLiteral(0, 0, value, strconv.FormatBool(value))
+}
+
+// Null returns the null Literal.
+func Null() parser.Node {
+	return parser.NewLiteral(0, 0, nil, "null")
+}
+
+// This returns a ThisExpression.
+func This() parser.Node {
+	return parser.NewThisExpression(0, 0)
+}
+
+// Super returns a Super, to be called or to have a member accessed.
+func Super() parser.Node {
+	return parser.NewSuperExpression(0, 0)
+}
+
+// Binary returns a BinaryExpression.
+func Binary(op string, left parser.Node, right parser.Node) parser.Node {
+	return parser.NewBinaryExpression(0, 0,
+		operator("Binary", op, "+", "-", "*", "/", "==", "!=", "===", "!==", "<", ">", "<=", ">="),
+		expression("Binary", "left", left),
+		expression("Binary", "right", right),
+	)
+}
+
+// Logical returns a LogicalExpression.
+func Logical(op string, left parser.Node, right parser.Node) parser.Node {
+	return parser.NewLogicalExpression(0, 0,
+		operator("Logical", op, "&&", "||"),
+		expression("Logical", "left", left),
+		expression("Logical", "right", right),
+	)
+}
+
+// Assign returns an AssignmentExpression to an Identifier or a
+// MemberExpression.
+func Assign(op string, target parser.Node, value parser.Node) parser.Node {
+	if target != nil && target.Not(parser.Identifier, parser.MemberExpression) {
+		panic(&BuildError{"Assign", "target", fmt.Sprintf("must be an Identifier or a MemberExpression, got %s", target.Type())})
+	}
+
+	return parser.NewAssignmentExpression(0, 0,
+		operator("Assign", op, "=", "+=", "-=", "*=", "/="),
+		expression("Assign", "target", target),
+		expression("Assign", "value", value),
+	)
+}
+
+// Unary returns a UnaryExpression.
+func Unary(op string, argument parser.Node) parser.Node {
+	return parser.NewUnaryExpression(0, 0,
+		operator("Unary", op, "!", "-", "+"),
+		expression("Unary", "argument", argument),
+	)
+}
+
+// Member returns the MemberExpression object.property.
+func Member(object parser.Node, property string) parser.Node {
+	return parser.NewMemberExpression(0, 0, member("Member", object), parser.NewIdentifier(0, 0, name("Member", "property", property)), false)
+}
+
+// Index returns the computed MemberExpression object[property].
+func Index(object parser.Node, property parser.Node) parser.Node {
+	return parser.NewMemberExpression(0, 0, member("Index", object), expression("Index", "property", property), true)
+}
+
+func member(builder string, object parser.Node) parser.Node {
+	if object != nil && object.Is(parser.SuperExpression) {
+		return object
+	}
+
+	return expression(builder, "object", object)
+}
+
+// Call returns a CallExpression.
+func Call(callee parser.Node, arguments ...parser.Node) parser.Node {
+	if callee == nil || callee.Not(parser.SuperExpression) {
+		expression("Call", "callee", callee)
+	}
+
+	args := make([]parser.Node, len(arguments))
+	for i, argument := range arguments {
+		args[i] = expression("Call", fmt.Sprintf("arguments[%d]", i), argument)
+	}
+
+	return parser.NewCallExpression(0, 0, callee, args)
+}
+
+func params(builder string, names []string) []parser.Node {
+	res := make([]parser.Node, len(names))
+	for i, n := range names {
+		res[i] = parser.NewIdentifier(0, 0, binding(builder, fmt.Sprintf("params[%d]", i), n))
+	}
+
+	return res
+}
+
+// Func returns an anonymous FunctionExpression.
+func Func(parameters []string, body ...parser.Node) parser.Node {
+	return parser.NewFunctionExpression(0, 0, params("Func", parameters), parser.NewBlockStatement(0, 0, statements("Func", "body", body)...))
+}
+
+// FuncDecl returns a FunctionDeclaration.
+func FuncDecl(id string, parameters []string, body ...parser.Node) parser.Node {
+	return parser.NewFunctionDeclaration(0, 0,
+		parser.NewIdentifier(0, 0, binding("FuncDecl", "id", id)),
+		params("FuncDecl", parameters),
+		parser.NewBlockStatement(0, 0, statements("FuncDecl", "body", body)...),
+	)
+}
+
+// Class returns a ClassDeclaration. The superclass may be nil.
+func Class(id string, superClass parser.Node, members ...parser.Node) parser.Node {
+	return parser.NewClassDeclaration(0, 0,
+		parser.NewIdentifier(0, 0, binding("Class", "id", id)),
+		optionalExpression("Class", "superClass", superClass),
+		classBody("Class", members),
+	)
+}
+
+// ClassExpr returns a ClassExpression. The id may be empty and the
+// superclass nil.
+func ClassExpr(id string, superClass parser.Node, members ...parser.Node) parser.Node {
+	var identifier parser.Node
+	if id != "" {
+		identifier = parser.NewIdentifier(0, 0, binding("ClassExpr", "id", id))
+	}
+
+	return parser.NewClassExpression(0, 0, identifier, optionalExpression("ClassExpr", "superClass", superClass), classBody("ClassExpr", members))
+}
+
+func classBody(builder string, members []parser.Node) parser.Node {
+	for i, m := range members {
+		if m == nil || m.Not(parser.MethodDefinition, parser.PropertyDefinition) {
+			panic(&BuildError{builder, fmt.Sprintf("members[%d]", i), "must be a MethodDefinition or a PropertyDefinition"})
+		}
+	}
+
+	return parser.NewClassBody(0, 0, members)
+}
+
+// Method returns a MethodDefinition, which is the constructor when named
+// "constructor".
+func Method(key string, parameters []string, body ...parser.Node) parser.Node {
+	kind := parser.Method
+	if key == string(parser.ConstructorMethod) {
+		kind = parser.ConstructorMethod
+	}
+
+	value := parser.NewFunctionExpression(0, 0, params("Method", parameters), parser.NewBlockStatement(0, 0, statements("Method", "body", body)...))
+
+	return parser.NewMethodDefinition(0, 0, parser.NewIdentifier(0, 0, name("Method", "key", key)), kind, value)
+}
+
+// Property returns a PropertyDefinition. The value may be nil.
+func Property(key string, value parser.Node) parser.Node {
+	return parser.NewPropertyDefinition(0, 0, parser.NewIdentifier(0, 0, name("Property", "key", key)), optionalExpression("Property", "value", value))
+}
+
+// Expr returns an ExpressionStatement.
+func Expr(expr parser.Node) parser.Node {
+	return parser.NewExpressionStatement(0, 0, expression("Expr", "expression", expr))
+}
+
+// Block returns a BlockStatement.
+func Block(body ...parser.Node) parser.Node {
+	return parser.NewBlockStatement(0, 0, statements("Block", "body", body)...)
+}
+
+// Empty returns an EmptyStatement.
+func Empty() parser.Node {
+	return parser.NewEmptyStatement(0, 0)
+}
+
+// Let returns the VariableDeclaration 'let id = init'. The init may be nil.
+func Let(id string, init parser.Node) parser.Node {
+	return declaration("Let", "let", id, init)
+}
+
+// Const returns the VariableDeclaration 'const id = init'.
+func Const(id string, init parser.Node) parser.Node {
+	return declaration("Const", "const", id, expression("Const", "init", init))
+}
+
+func declaration(builder string, kind string, id string, init parser.Node) parser.Node {
+	declarator := parser.NewVariableDeclarator(0, 0, parser.NewIdentifier(0, 0, binding(builder, "id", id)), optionalExpression(builder, "init", init))
+
+	return parser.NewVariableDeclaration(0, 0, kind, []parser.Node{declarator})
+}
+
+// If returns an IfStatement. The alternate may be nil.
+func If(test parser.Node, consequent parser.Node, alternate parser.Node) parser.Node {
+	if alternate != nil {
+		alternate = statement("If", "alternate", alternate)
+	}
+
+	return parser.NewIfStatement(0, 0, expression("If", "test", test), statement("If", "consequent", consequent), alternate)
+}
+
+// While returns a WhileStatement.
+func While(test parser.Node, body parser.Node) parser.Node {
+	return parser.NewWhileStatement(0, 0, expression("While", "test", test), statement("While", "body", body))
+}
+
+// DoWhile returns a DoWhileStatement.
+func DoWhile(body parser.Node, test parser.Node) parser.Node {
+	return parser.NewDoWhileStatement(0, 0, expression("DoWhile", "test", test), statement("DoWhile", "body", body))
+}
+
+// For returns a ForStatement. The init, which is a VariableDeclaration or an
+// expression, the test and the update may be nil.
+func For(init parser.Node, test parser.Node, update parser.Node, body parser.Node) parser.Node {
+	if init != nil && init.Not(parser.VariableDeclaration) {
+		init = expression("For", "init", init)
+	}
+
+	return parser.NewForStatement(0, 0,
+		init,
+		optionalExpression("For", "test", test),
+		optionalExpression("For", "update", update),
+		statement("For", "body", body),
+	)
+}
+
+// Return returns a ReturnStatement. The argument may be nil.
+func Return(argument parser.Node) parser.Node {
+	return parser.NewReturnStatement(0, 0, optionalExpression("Return", "argument", argument))
+}
+
+// Program returns a Program.
+func Program(body ...parser.Node) parser.Node {
+	return parser.NewProgram(0, 0, statements("Program", "body", body)...)
+}
@@ -0,0 +1,133 @@
+package builder_test
+
+import (
+	"errors"
+	"testing"
+
+	b "github.com/0xvesion/go-js-parser/builder"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/schema"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected parser.Node
+	}{
+		{`console.log("hi");`, b.Program(b.Call(b.Member(b.Ident("console"), "log"), b.Str("hi")))},
+		{`let a = 1; const b = "x" + 25 * -3;`, b.Program(
+			b.Let("a", b.Num(1)),
+			b.Const("b", b.Binary("+", b.Str("x"), b.Binary("*", b.Num(25), b.Num(-3)))),
+		)},
+		{`let a; a += 1; this.x[a] = null;`, b.Program(
+			b.Let("a", nil),
+			b.Assign("+=", b.Ident("a"), b.Num(1)),
+			b.Assign("=", b.Index(b.Member(b.This(), "x"), b.Ident("a")), b.Null()),
+		)},
+		{`if (!a && b || true) { ; } else while (false) do a = 1; while (a !== 2);`, b.Program(
+			b.If(
+				b.Logical("||", b.Logical("&&", b.Unary("!", b.Ident("a")), b.Ident("b")), b.Bool(true)),
+				b.Block(b.Empty()),
+				b.While(b.Bool(false), b.DoWhile(b.Assign("=", b.Ident("a"), b.Num(1)), b.Binary("!==", b.Ident("a"), b.Num(2)))),
+			),
+		)},
+		{`for (let i = 0; i < 10; i += 1) f(i);`, b.Program(
+			b.For(b.Let("i", b.Num(0)), b.Binary("<", b.Ident("i"), b.Num(10)), b.Assign("+=", b.Ident("i"), b.Num(1)), b.Call(b.Ident("f"), b.Ident("i"))),
+		)},
+		{`function f(a, b) { return a; } class A extends B { constructor() { super(); } m(x) {} y = 2; z; }`, b.Program(
+			b.FuncDecl("f", []string{"a", "b"}, b.Return(b.Ident("a"))),
+			b.Class("A", b.Ident("B"),
+				b.Method("constructor", nil, b.Call(b.Super())),
+				b.Method("m", []string{"x"}),
+				b.Property("y", b.Num(2)),
+				b.Property("z", nil),
+			),
+		)},
+		{`let C = class { m() { return; } };`, b.Program(
+			b.Let("C", b.ClassExpr("", nil, b.Method("m", nil, b.Return(nil)))),
+		)},
+		{`x = 3000000000;`, b.Program(b.Assign("=", b.Ident("x"), b.Num(3000000000)))},
+	}
+
+	for _, test := range tests {
+		expected, err := parser.New(tokenizer.New(test.src)).Parse()
+		if err != nil {
+			t.Fatalf("%s: %v", test.src, err)
+		}
+
+		if !parser.Equal(test.expected, expected, parser.CodeOptions) {
+			t.Errorf("The built node doesn't match %s.\nwant: %v\ngot: %v", test.src, expected, test.expected)
+		}
+		for _, err := range schema.Validate(test.expected) {
+			t.Errorf("%s: %v", test.src, err)
+		}
+		if test.expected.Start() != 0 || test.expected.End() != 0 {
+			t.Errorf("Expected no positions for %s.", test.src)
+		}
+	}
+}
+
+func TestRaw(t *testing.T) {
+	tests := []struct {
+		n   parser.Node
+		raw string
+	}{
+		{b.Str(`a"b\c` + "\t\u2028"), `"a\"b\\c\t\u2028"`},
+		{b.Num(1e21), "1e+21"},
+		{b.Num(0.5), "0.5"},
+		{b.Num(42), "42"},
+		{b.Num(3000000000), "3000000000"},
+		{b.Bool(false), "false"},
+	}
+
+	for _, test := range tests {
+		if raw := test.n["raw"]; raw != test.raw {
+			t.Errorf("Expected the raw value %s, got: %v", test.raw, raw)
+		}
+	}
+}
+
+func TestValidation(t *testing.T) {
+	tests := []struct {
+		build    func() parser.Node
+		expected string
+	}{
+		{func() parser.Node { return b.Binary("**", b.Num(1), b.Num(2)) }, "builder.Binary: operator must be one of + - * / == != === !== < > <= >=, got '**'"},
+		{func() parser.Node { return b.Binary("+", b.Num(1), b.Empty()) }, "builder.Binary: right must be an expression, got EmptyStatement"},
+		{func() parser.Node { return b.Call(nil) }, "builder.Call: callee is missing"},
+		{func() parser.Node { return b.Call(b.Ident("f"), b.Ident("a"), b.Block()) }, "builder.Call: arguments[1] must be an expression, got BlockStatement"},
+		{func() parser.Node { return b.Assign("=", b.Num(1), b.Num(2)) }, "builder.Assign: target must be an Identifier or a MemberExpression, got Literal"},
+		{func() parser.Node { return b.Ident("a-b") }, `builder.Ident: name must be an identifier, got "a-b"`},
+		{func() parser.Node { return b.Member(b.Ident("a"), "1") }, `builder.Member: property must be an identifier, got "1"`},
+		{func() parser.Node { return b.Expr(b.Ident("if")) }, `builder.Ident: name must not be a reserved word, got "if"`},
+		{func() parser.Node { return b.Let("class", b.Num(1)) }, `builder.Let: id must not be a reserved word, got "class"`},
+		{func() parser.Node { return b.Func([]string{"static"}) }, `builder.Func: params[0] must not be a reserved word, got "static"`},
+		{func() parser.Node { return b.Block(b.Ident("a"), b.Method("m", nil)) }, "builder.Block: body[1] must be a statement, got MethodDefinition"},
+		{func() parser.Node { return b.Class("A", nil, b.Empty()) }, "builder.Class: members[0] must be a MethodDefinition or a PropertyDefinition"},
+		{func() parser.Node { return b.FuncDecl("f", []string{"a", ""}) }, `builder.FuncDecl: params[1] must be an identifier, got ""`},
+		{func() parser.Node { return b.Const("a", nil) }, "builder.Const: init is missing"},
+	}
+
+	for _, test := range tests {
+		n, err := b.Try(test.build)
+
+		var buildErr *b.BuildError
+		if n != nil || !errors.As(err, &buildErr) || err.Error() != test.expected {
+			t.Errorf("Expected the error %q, got: %v", test.expected, err)
+		}
+	}
+
+	if _, err := b.Try(func() parser.Node { return b.Member(b.Ident("a"), "class") }); err != nil {
+		t.Errorf("Expected reserved words to name properties, got: %v", err)
+	}
+}
+
+func TestExpressionStatements(t *testing.T) {
+	program := b.Program(b.Ident("a"))
+
+	statement := program["body"].([]parser.Node)[0]
+	if statement.Type() != parser.ExpressionStatement {
+		t.Errorf("Expected the expression to be wrapped in an ExpressionStatement, got: %s", statement.Type())
+	}
+}
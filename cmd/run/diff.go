@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around the changes of a hunk.
+const diffContext = 3
+
+// maxDiffCells bounds the table of the longest common subsequence. Larger
+// changes are shown as replacing every line between the common prefix and
+// suffix.
+const maxDiffCells = 1 << 24
+
+// edit is a line of a diff: ' ' kept, '-' removed or '+' added.
+type edit struct {
+	op   byte
+	line string
+}
+
+// unifiedDiff returns the changes from a to b in the unified format, or an
+// empty string if there are none.
+func unifiedDiff(name, a, b string) string {
+	edits := diffLines(splitLines(a), splitLines(b))
+
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", name, name)
+
+	changed := false
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		changed = true
+
+		// A hunk extends to the following change while the context around
+		// them overlaps or touches.
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		end := i
+		for j := i; j < len(edits) && j <= end+2*diffContext+1; j++ {
+			if edits[j].op != ' ' {
+				end = j
+			}
+		}
+		end += diffContext + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		out.WriteString(hunk(edits, start, end))
+		i = end
+	}
+
+	if !changed {
+		return ""
+	}
+
+	return out.String()
+}
+
+// hunk formats the edits from start to end.
+func hunk(edits []edit, start, end int) string {
+	lineA, lineB := 1, 1
+	for _, e := range edits[:start] {
+		if e.op != '+' {
+			lineA++
+		}
+		if e.op != '-' {
+			lineB++
+		}
+	}
+
+	countA, countB := 0, 0
+	body := strings.Builder{}
+	for _, e := range edits[start:end] {
+		if e.op != '+' {
+			countA++
+		}
+		if e.op != '-' {
+			countB++
+		}
+
+		body.WriteByte(e.op)
+		body.WriteString(e.line)
+		if !strings.HasSuffix(e.line, "\n") {
+			body.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+
+	// An empty range starts at the line before it.
+	if countA == 0 {
+		lineA--
+	}
+	if countB == 0 {
+		lineB--
+	}
+
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", lineA, countA, lineB, countB, body.String())
+}
+
+// splitLines splits a text after every newline.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// diffLines returns the edits turning a into b, from a longest common
+// subsequence of their lines.
+func diffLines(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := []edit{}
+	for _, line := range a[:prefix] {
+		edits = append(edits, edit{' ', line})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if (len(midA)+1)*(len(midB)+1) > maxDiffCells {
+		for _, line := range midA {
+			edits = append(edits, edit{'-', line})
+		}
+		for _, line := range midB {
+			edits = append(edits, edit{'+', line})
+		}
+	} else {
+		edits = append(edits, lcsEdits(midA, midB)...)
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, edit{' ', line})
+	}
+
+	return edits
+}
+
+func lcsEdits(a, b []string) []edit {
+	// lengths[i][j] is the length of the longest common subsequence of
+	// a[i:] and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{'+', b[j]})
+	}
+
+	return edits
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	lines := func(prefix string, from, to int) string {
+		res := strings.Builder{}
+		for i := from; i <= to; i++ {
+			res.WriteString(prefix + strings.Repeat("x", i) + "\n")
+		}
+
+		return res.String()
+	}
+
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"a\nb\nc\n", "a\nB\nc\n", "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"},
+		{"a", "b", "@@ -1,1 +1,1 @@\n-a\n\\ No newline at end of file\n+b\n\\ No newline at end of file\n"},
+		{"", "a\n", "@@ -0,0 +1,1 @@\n+a\n"},
+		{"a\nb\n", "b\n", "@@ -1,2 +1,1 @@\n-a\n b\n"},
+		{
+			"first\n" + lines("", 2, 9) + "last\n",
+			"1\n" + lines("", 2, 9) + "10\n",
+			"@@ -1,4 +1,4 @@\n-first\n+1\n xx\n xxx\n xxxx\n" +
+				"@@ -7,4 +7,4 @@\n xxxxxxx\n xxxxxxxx\n xxxxxxxxx\n-last\n+10\n",
+		},
+		{
+			"first\n" + lines("", 2, 7) + "last\n",
+			"1\n" + lines("", 2, 7) + "8\n",
+			"@@ -1,8 +1,8 @@\n-first\n+1\n" + lines(" ", 2, 7) + "-last\n+8\n",
+		},
+	}
+
+	for _, test := range tests {
+		actual := unifiedDiff("a.js", test.a, test.b)
+
+		expected := test.expected
+		if expected != "" {
+			expected = "--- a/a.js\n+++ b/a.js\n" + expected
+		}
+		if actual != expected {
+			t.Errorf("Unexpected diff of %q and %q.\nwant: %q\ngot:  %q", test.a, test.b, expected, actual)
+		}
+	}
+}
+
+func TestLargeDiff(t *testing.T) {
+	a, b := []string{}, []string{}
+	for i := 0; i < 5000; i++ {
+		a = append(a, "a\n")
+		b = append(b, "b\n")
+	}
+
+	edits := diffLines(append([]string{"same\n"}, a...), append([]string{"same\n"}, b...))
+	if len(edits) != 10001 || edits[0].op != ' ' || edits[1].op != '-' || edits[10000].op != '+' {
+		t.Errorf("Expected the lines to be replaced, got %d edits", len(edits))
+	}
+}
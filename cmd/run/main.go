@@ -1,21 +1,292 @@
+// Command run parses and formats JavaScript:
+//
+//	run fmt [flags] [path ...]
+//	run parse [flags] [path]
+//
+// fmt formats the given files, and the JavaScript files in the given
+// directories, or the standard input. It prints the formatted code, unless
+// --write, --check or --diff is set. parse prints the tree of a file, or of
+// the standard input, as JSON.
 package main
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/0xvesion/go-js-parser/diagnostics"
+	"github.com/0xvesion/go-js-parser/format"
 	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
 	"github.com/0xvesion/go-js-parser/tokenizer"
 )
 
+const usage = `usage: run fmt [flags] [path ...]
+       run parse [flags] [path]
+`
+
 func main() {
-	ast, err := parser.New(tokenizer.New(`function test(a, b, c) {
-		result = 123;
-	}`)).Parse()
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "fmt":
+		os.Exit(fmtCommand(os.Args[2:]))
+	case "parse":
+		os.Exit(parseCommand(os.Args[2:]))
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// extensions are the syntax extensions of the files fmt formats. Other files
+// are only formatted when given by name, as plain JavaScript with JSX.
+var extensions = map[string]parser.Options{
+	".js":  {JSX: true, Decorators: true},
+	".jsx": {JSX: true, Decorators: true},
+	".mjs": {JSX: true, Decorators: true},
+	".cjs": {JSX: true, Decorators: true},
+	".ts":  {TypeScript: true, Decorators: true},
+	".mts": {TypeScript: true, Decorators: true},
+	".cts": {TypeScript: true, Decorators: true},
+	".tsx": {TypeScript: true, JSX: true, Decorators: true},
+}
+
+func syntax(path string) parser.Options {
+	if options, ok := extensions[filepath.Ext(path)]; ok {
+		return options
+	}
+
+	return extensions[".js"]
+}
+
+func fmtCommand(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := flags.Bool("write", false, "write the formatted code to the files")
+	check := flags.Bool("check", false, "list the files which aren't formatted and fail if there are any")
+	diff := flags.Bool("diff", false, "print the changes as unified diffs")
+	width := flags.Int("width", format.DefaultOptions.Width, "the line width")
+	indent := flags.Int("indent", len(format.DefaultOptions.Indent), "the number of spaces to indent with")
+	tabs := flags.Bool("tabs", false, "indent with tabs")
+	singleQuote := flags.Bool("single-quote", false, "prefer single quotes")
+	semi := flags.Bool("semi", true, "end every statement with a semicolon")
+	typescript := flags.Bool("ts", false, "read the standard input as TypeScript")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "usage: run fmt [flags] [path ...]\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	options := format.DefaultOptions
+	options.Width = *width
+	options.Indent = strings.Repeat(" ", *indent)
+	if *tabs {
+		options.Indent = "\t"
+	}
+	if *singleQuote {
+		options.Quote = '\''
+	}
+	if !*semi {
+		options.Semicolons = printer.SemicolonsAsNeeded
+	}
+
+	f := &formatter{options: options, write: *write, check: *check, diff: *diff}
+
+	if flags.NArg() == 0 {
+		if *write {
+			fmt.Fprintln(os.Stderr, "run fmt: cannot use --write with the standard input")
+			return 2
+		}
+
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+
+		stdinSyntax := extensions[".js"]
+		if *typescript {
+			stdinSyntax = extensions[".ts"]
+		}
+		f.format("<standard input>", src, stdinSyntax)
+
+		return f.status()
+	}
+
+	for _, path := range flags.Args() {
+		f.path(path)
+	}
+
+	return f.status()
+}
+
+// formatter formats files and remembers how it went.
+type formatter struct {
+	options format.Options
+	write   bool
+	check   bool
+	diff    bool
+	// failed is set after an error, and unformatted after finding a file
+	// which isn't formatted.
+	failed      bool
+	unformatted bool
+}
+
+func (f *formatter) status() int {
+	switch {
+	case f.failed:
+		return 2
+	case f.check && f.unformatted:
+		return 1
+	}
+
+	return 0
+}
+
+func (f *formatter) error(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	f.failed = true
+}
+
+// path formats a file, or the JavaScript files below a directory.
+func (f *formatter) path(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		f.error(err)
+		return
+	}
+
+	if !info.IsDir() {
+		f.file(path, info.Mode())
+		return
+	}
+
+	err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			f.error(err)
+			return nil
+		}
+
+		name := d.Name()
+		if d.IsDir() {
+			if name == "node_modules" || len(name) > 1 && strings.HasPrefix(name, ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if _, ok := extensions[filepath.Ext(name)]; ok && !strings.HasPrefix(name, ".") {
+			info, err := d.Info()
+			if err != nil {
+				f.error(err)
+				return nil
+			}
+
+			f.file(path, info.Mode())
+		}
+
+		return nil
+	})
 	if err != nil {
-		panic(err)
+		f.error(err)
+	}
+}
+
+func (f *formatter) file(path string, mode fs.FileMode) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		f.error(err)
+		return
+	}
+
+	formatted, ok := f.format(path, src, syntax(path))
+	if ok && f.write && string(formatted) != string(src) {
+		if err := os.WriteFile(path, formatted, mode.Perm()); err != nil {
+			f.error(err)
+		}
+	}
+}
+
+// format formats a source and reports it as the flags ask.
+func (f *formatter) format(name string, src []byte, syntax parser.Options) ([]byte, bool) {
+	options := f.options
+	options.Syntax = syntax
+
+	formatted, err := format.Source(src, options)
+	if err != nil {
+		if errors.Is(err, format.ErrChanged) {
+			f.error(fmt.Errorf("%s: %w", name, err))
+		} else {
+			f.error(fmt.Errorf("%s:\n%s", name, diagnostics.RenderError(string(src), err, diagnostics.DefaultOptions)))
+		}
+
+		return nil, false
+	}
+
+	changed := string(formatted) != string(src)
+	if changed {
+		f.unformatted = true
+	}
+
+	switch {
+	case f.diff:
+		if changed {
+			fmt.Print(unifiedDiff(name, string(src), string(formatted)))
+		}
+	case f.check:
+		if changed {
+			fmt.Println(name)
+		}
+	case !f.write:
+		os.Stdout.Write(formatted)
+	}
+
+	return formatted, true
+}
+
+func parseCommand(args []string) int {
+	flags := flag.NewFlagSet("parse", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "usage: run parse [flags] [path]\n")
+		flags.PrintDefaults()
+	}
+	locations := flags.Bool("locations", false, "add the lines and columns of the nodes")
+	flags.Parse(args)
+
+	var src []byte
+	var err error
+	options := extensions[".js"]
+	if path := flags.Arg(0); path != "" {
+		src, err = os.ReadFile(path)
+		options = syntax(path)
+		options.SourceFile = path
+	} else {
+		src, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	options.Locations = *locations
+	ast, err := parser.NewWithOptions(tokenizer.New(string(src)), options).Parse()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, diagnostics.RenderError(string(src), err, diagnostics.DefaultOptions))
+		return 1
 	}
 
 	j, _ := json.MarshalIndent(ast, "", "  ")
 	fmt.Println(string(j))
+
+	return 0
 }
@@ -0,0 +1,272 @@
+// Package diagnostics renders errors as code frames, which show the source
+// lines around an error and underline the range it refers to:
+//
+//	  1 | let a = 1;
+//	> 2 | let b = ;
+//	    |         ^ invalid token: Semicolon
+//	  3 | let c = 3;
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+// Diagnostic is a message about a range of a source. An empty range points at
+// the character at Start.
+type Diagnostic struct {
+	Start   int
+	End     int
+	Message string
+}
+
+// Options configure how code frames are rendered.
+type Options struct {
+	// LinesAbove and LinesBelow are the number of context lines shown
+	// around the lines of the range.
+	LinesAbove int
+	LinesBelow int
+	// TabWidth expands tabs to the given number of columns. Zero keeps the
+	// tabs, and the underline repeats them to stay aligned.
+	TabWidth int
+	// Color highlights the frame with ANSI escape sequences. Leave it off
+	// for plain text, e.g. in logs.
+	Color bool
+	// Encoding is the unit of the diagnostic offsets.
+	Encoding tokenizer.Encoding
+}
+
+// DefaultOptions are the options used by Babel.
+var DefaultOptions = Options{LinesAbove: 2, LinesBelow: 3}
+
+// ranger is implemented by errors which know the range they refer to.
+type ranger interface {
+	Range() (start int, end int)
+}
+
+// FromError returns the diagnostics of an error. It understands
+// parser.ErrorList, *parser.SyntaxError, *tokenizer.LexError and errors with
+// a Range() (start, end int) method. Other errors have no position and are
+// returned as a single diagnostic without a range, at offset -1.
+func FromError(err error) []Diagnostic {
+	var list parser.ErrorList
+	if errors.As(err, &list) {
+		diagnostics := []Diagnostic{}
+		for _, err := range list {
+			diagnostics = append(diagnostics, FromError(err)...)
+		}
+
+		return diagnostics
+	}
+
+	var syntaxErr *parser.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		end := syntaxErr.End
+		if end < syntaxErr.Offset {
+			end = syntaxErr.Offset
+		}
+
+		return []Diagnostic{{syntaxErr.Offset, end, syntaxErr.Message}}
+	}
+
+	var lexErr *tokenizer.LexError
+	if errors.As(err, &lexErr) {
+		return []Diagnostic{{lexErr.Offset, lexErr.Offset, lexErr.Message}}
+	}
+
+	var r ranger
+	if errors.As(err, &r) {
+		start, end := r.Range()
+
+		return []Diagnostic{{start, end, err.Error()}}
+	}
+
+	return []Diagnostic{{-1, -1, err.Error()}}
+}
+
+// RenderError renders the code frames of every diagnostic of an error.
+func RenderError(src string, err error, options Options) string {
+	return RenderAll(src, FromError(err), options)
+}
+
+// RenderAll renders the code frames of several diagnostics, separated by an
+// empty line.
+func RenderAll(src string, diagnostics []Diagnostic, options Options) string {
+	frames := make([]string, len(diagnostics))
+	for i, d := range diagnostics {
+		frames[i] = Render(src, d, options)
+	}
+
+	return strings.Join(frames, "\n\n")
+}
+
+// Render renders the code frame of a diagnostic. A diagnostic without a
+// position renders as its message.
+func Render(src string, d Diagnostic, options Options) string {
+	if d.Start < 0 {
+		return d.Message
+	}
+
+	r := renderer{src: src, options: options, lines: tokenizer.NewLineIndex(src, tokenizer.Bytes)}
+
+	return r.render(d)
+}
+
+const (
+	gray  = "\x1b[90m"
+	red   = "\x1b[31;1m"
+	reset = "\x1b[0m"
+)
+
+type renderer struct {
+	src     string
+	options Options
+	lines   *tokenizer.LineIndex
+}
+
+func (r *renderer) render(d Diagnostic) string {
+	start := r.options.Encoding.ToBytes(r.src, d.Start)
+	end := r.options.Encoding.ToBytes(r.src, d.End)
+	if start > len(r.src) {
+		start = len(r.src)
+	}
+	if end < start {
+		end = start
+	}
+
+	startLine := r.lines.Position(start).Line
+	endLine := r.lines.Position(end).Line
+	// A range ending at the start of a line doesn't cover it.
+	if end > start && endLine > startLine && r.lines.LineStart(endLine) == end {
+		endLine--
+	}
+
+	first := startLine - r.options.LinesAbove
+	if first < 1 {
+		first = 1
+	}
+	last := endLine + r.options.LinesBelow
+	if last > r.lines.Lines() {
+		last = r.lines.Lines()
+	}
+
+	width := len(fmt.Sprint(last))
+	res := []string{}
+
+	for line := first; line <= last; line++ {
+		text := r.line(line)
+		marked := line >= startLine && line <= endLine
+
+		prefix := " "
+		if marked {
+			prefix = r.color(red, ">")
+		}
+
+		gutter := prefix + r.color(gray, fmt.Sprintf(" %*d |", width, line))
+		res = append(res, strings.TrimRight(gutter+" "+r.expand(text), " "))
+		if !marked {
+			continue
+		}
+
+		lineStart := r.lines.LineStart(line)
+		from, to := 0, len(text)
+		if line == startLine {
+			from = start - lineStart
+		}
+		if line == endLine {
+			to = end - lineStart
+		}
+
+		marker := " " + r.color(gray, fmt.Sprintf(" %*s |", width, "")) + " " + r.marker(text, from, to)
+		if line == endLine && d.Message != "" {
+			marker += " " + r.color(red, d.Message)
+		}
+		res = append(res, marker)
+	}
+
+	return strings.Join(res, "\n")
+}
+
+// line returns the text of a 1-based line, without its terminator.
+func (r *renderer) line(line int) string {
+	end := len(r.src)
+	if line < r.lines.Lines() {
+		end = r.lines.LineStart(line + 1)
+	}
+
+	text := r.src[r.lines.LineStart(line):end]
+	for _, terminator := range []string{"\r\n", "\n", "\r", "\u2028", "\u2029"} {
+		if strings.HasSuffix(text, terminator) {
+			return strings.TrimSuffix(text, terminator)
+		}
+	}
+
+	return text
+}
+
+// expand replaces tabs with spaces up to the next tab stop.
+func (r *renderer) expand(text string) string {
+	if r.options.TabWidth <= 0 {
+		return text
+	}
+
+	res := strings.Builder{}
+	column := 0
+	for _, c := range text {
+		if c == '\t' {
+			spaces := r.options.TabWidth - column%r.options.TabWidth
+			res.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+
+			continue
+		}
+
+		res.WriteRune(c)
+		column++
+	}
+
+	return res.String()
+}
+
+// marker underlines the bytes from..to of a line, with at least one caret.
+func (r *renderer) marker(text string, from int, to int) string {
+	if from > len(text) {
+		from = len(text)
+	}
+	if to > len(text) {
+		to = len(text)
+	}
+
+	padding := r.expand(text[:from])
+	if r.options.TabWidth <= 0 {
+		padding = strings.Map(func(c rune) rune {
+			if c == '\t' {
+				return c
+			}
+
+			return ' '
+		}, padding)
+	} else {
+		padding = strings.Repeat(" ", utf8.RuneCountInString(padding))
+	}
+
+	length := utf8.RuneCountInString(r.expand(text[:to])) - utf8.RuneCountInString(r.expand(text[:from]))
+	if length < 1 {
+		length = 1
+	}
+
+	return padding + r.color(red, strings.Repeat("^", length))
+}
+
+func (r *renderer) color(color string, s string) string {
+	if !r.options.Color {
+		return s
+	}
+
+	return color + s + reset
+}
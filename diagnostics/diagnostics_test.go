@@ -0,0 +1,120 @@
+package diagnostics_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/diagnostics"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+func frameTest(t *testing.T, src string, d diagnostics.Diagnostic, options diagnostics.Options, expected ...string) {
+	actual := diagnostics.Render(src, d, options)
+
+	if want := strings.Join(expected, "\n"); actual != want {
+		t.Errorf("Invalid code frame.\nwant:\n%s\ngot:\n%s", want, actual)
+	}
+}
+
+func TestRender(t *testing.T) {
+	src := "a;\nb;\nlet x = ;\nc;\nd;\ne;\nf;"
+
+	frameTest(t, src, diagnostics.Diagnostic{Start: 14, End: 15, Message: "oops"}, diagnostics.DefaultOptions,
+		"  1 | a;",
+		"  2 | b;",
+		"> 3 | let x = ;",
+		"    |         ^ oops",
+		"  4 | c;",
+		"  5 | d;",
+		"  6 | e;",
+	)
+
+	frameTest(t, src, diagnostics.Diagnostic{Start: 6, End: 11, Message: "oops"}, diagnostics.Options{},
+		"> 3 | let x = ;",
+		"    | ^^^^^ oops",
+	)
+}
+
+func TestRenderMultiLineRange(t *testing.T) {
+	src := "x = {\n  a;\n};\n"
+
+	frameTest(t, src, diagnostics.Diagnostic{Start: 4, End: 12, Message: "block"}, diagnostics.Options{LinesBelow: 1},
+		"> 1 | x = {",
+		"    |     ^",
+		"> 2 |   a;",
+		"    | ^^^^",
+		"> 3 | };",
+		"    | ^ block",
+		"  4 |",
+	)
+}
+
+func TestRenderTabs(t *testing.T) {
+	src := "\tx y;"
+	d := diagnostics.Diagnostic{Start: 3, End: 4, Message: "y"}
+
+	frameTest(t, src, d, diagnostics.Options{},
+		"> 1 | \tx y;",
+		"    | \t  ^ y",
+	)
+	frameTest(t, src, d, diagnostics.Options{TabWidth: 4},
+		"> 1 |     x y;",
+		"    |       ^ y",
+	)
+}
+
+func TestRenderWideLineNumbers(t *testing.T) {
+	src := strings.Repeat("x;\n", 9) + "y;"
+
+	frameTest(t, src, diagnostics.Diagnostic{Start: 27, End: 28}, diagnostics.Options{LinesAbove: 1},
+		"   9 | x;",
+		"> 10 | y;",
+		"     | ^",
+	)
+}
+
+func TestRenderColor(t *testing.T) {
+	actual := diagnostics.Render("x", diagnostics.Diagnostic{Start: 0, End: 1, Message: "m"}, diagnostics.Options{Color: true})
+
+	if !strings.Contains(actual, "\x1b[31;1m^\x1b[0m") || !strings.Contains(actual, "\x1b[31;1mm\x1b[0m") {
+		t.Errorf("Expected a colored marker and message, got: %q", actual)
+	}
+	if strings.Contains(diagnostics.Render("x", diagnostics.Diagnostic{Start: 0, End: 1}, diagnostics.Options{}), "\x1b") {
+		t.Error("Expected no escape sequences in plain text")
+	}
+}
+
+func TestRenderEncoding(t *testing.T) {
+	src := `"😀" x y;`
+	d := diagnostics.Diagnostic{Start: 7, End: 8, Message: "y"}
+
+	frameTest(t, src, d, diagnostics.Options{Encoding: tokenizer.UTF16},
+		`> 1 | "😀" x y;`,
+		"    |       ^ y",
+	)
+}
+
+func TestRenderError(t *testing.T) {
+	src := "let a = ;\nx y;"
+	_, err := parser.NewWithOptions(tokenizer.New(src), parser.Options{Tolerant: true}).Parse()
+
+	diagnosticList := diagnostics.FromError(err)
+	if len(diagnosticList) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got: %v", diagnosticList)
+	}
+	if d := diagnosticList[1]; d.Start != 12 || d.End != 13 {
+		t.Errorf("Expected the range of the found token, got: %v", d)
+	}
+
+	actual := diagnostics.RenderError(src, err, diagnostics.Options{})
+	expected := "> 1 | let a = ;\n    |         ^ invalid token: Semicolon\n\n> 2 | x y;\n    |   ^ unexpected token type. want: Semicolon got: Identifier"
+	if actual != expected {
+		t.Errorf("Invalid code frames.\nwant:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if actual := diagnostics.RenderError(src, errors.New("no position"), diagnostics.DefaultOptions); actual != "no position" {
+		t.Errorf("Expected the message of an error without a position, got: %q", actual)
+	}
+}
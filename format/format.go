@@ -0,0 +1,102 @@
+// Package format formats JavaScript in one style, like gofmt does Go:
+//
+//	formatted, err := format.Source(src, format.DefaultOptions)
+//
+// The source is parsed and printed back by the printer, keeping its comments
+// and the single blank lines between statements. Formatting is idempotent:
+// formatting formatted code leaves it as it is.
+package format
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+// Options configure the style of the formatted code.
+type Options struct {
+	// Indent is the string for one level of indentation.
+	Indent string
+	// Width is the line width lists are broken to keep to.
+	Width int
+	// Quote is the preferred quote of string literals, '"' or '\''.
+	Quote byte
+	// Semicolons tells which statements end with a semicolon.
+	Semicolons printer.Semicolons
+	// Syntax enables the syntax extensions of the parser. Tolerant and
+	// Locations are ignored.
+	Syntax parser.Options
+}
+
+// DefaultOptions indent with two spaces, keep to 80 columns, prefer double
+// quotes and end every statement with a semicolon.
+var DefaultOptions = Options{
+	Indent:     "  ",
+	Width:      80,
+	Quote:      '"',
+	Semicolons: printer.SemicolonsAlways,
+}
+
+// ErrChanged is returned when the formatted code doesn't have the tree of the
+// source, which is a bug of the printer.
+var ErrChanged = errors.New("format: the formatted code doesn't match the source")
+
+// Source formats a program. Syntax errors are returned as the parser reports
+// them.
+func Source(src []byte, options Options) ([]byte, error) {
+	program, err := parse(string(src), options.Syntax)
+	if err != nil {
+		return nil, err
+	}
+	removeEmptyStatements(program)
+
+	formatted, err := printer.Print(program, printer.Options{
+		Indent:     options.Indent,
+		Width:      options.Width,
+		Quote:      options.Quote,
+		Semicolons: options.Semicolons,
+		Source:     string(src),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Formatting must never change what the code means.
+	check, err := parse(formatted, options.Syntax)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrChanged, err)
+	}
+	if !parser.Equal(program, check, parser.CodeOptions) {
+		return nil, ErrChanged
+	}
+
+	return []byte(formatted), nil
+}
+
+func parse(src string, options parser.Options) (parser.Node, error) {
+	options.Tolerant, options.Locations = false, false
+
+	return parser.NewWithOptions(tokenizer.New(src), options).Parse()
+}
+
+// removeEmptyStatements removes the stray semicolons from statement lists, as
+// in 'function f() {};'. The bodies of loops and ifs keep theirs.
+func removeEmptyStatements(n parser.Node) {
+	walk.Inspect(n, func(n parser.Node) bool {
+		if n.Is(parser.Program, parser.BlockStatement) {
+			body := []parser.Node{}
+			for _, statement := range n["body"].([]parser.Node) {
+				if statement.Not(parser.EmptyStatement) {
+					body = append(body, statement)
+				}
+			}
+			n["body"] = body
+		}
+
+		return true
+	})
+}
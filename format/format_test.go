@@ -0,0 +1,202 @@
+package format_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/format"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
+)
+
+var (
+	typescript = parser.Options{TypeScript: true, Decorators: true}
+	jsx        = parser.Options{JSX: true}
+	noSemi     = format.Options{Indent: "  ", Width: 80, Quote: '"', Semicolons: printer.SemicolonsAsNeeded}
+)
+
+func options(syntax parser.Options) format.Options {
+	options := format.DefaultOptions
+	options.Syntax = syntax
+
+	return options
+}
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+		options  format.Options
+	}{
+		{
+			"let a=1\nlet b = 'b'  ;;\nfunction f(a,b){return a+b};",
+			"let a = 1;\nlet b = \"b\";\nfunction f(a, b) {\n  return a + b;\n}\n",
+			format.DefaultOptions,
+		},
+		{
+			"// Header\n\n\n\na; // trailing\n/* before */\nb;\n\n// end\n",
+			"// Header\n\na; // trailing\n/* before */\nb;\n\n// end\n",
+			format.DefaultOptions,
+		},
+		{
+			"function f(a, /* b */ b) { // first\n  return a + /* c */ b; }\nfunction g() { /* empty */ }",
+			"function f(a, /* b */ b) {\n  // first\n  return a + /* c */ b;\n}\nfunction g() {\n  /* empty */\n}\n",
+			format.DefaultOptions,
+		},
+		{
+			"f(a, // a\n  b);\nclass A {\n  // x\n  x = 1; /* one */\n\n  m() {} // m\n}",
+			"f(a, b); // a\nclass A {\n  // x\n  x = 1; /* one */\n\n  m() {} // m\n}\n",
+			format.DefaultOptions,
+		},
+		{
+			"someFunction(argumentNumberOne, argumentNumberTwo, argumentNumberThree, fourthArgument);",
+			"someFunction(\n  argumentNumberOne,\n  argumentNumberTwo,\n  argumentNumberThree,\n  fourthArgument\n);\n",
+			format.DefaultOptions,
+		},
+		{
+			"function f(parameterNumberOne, parameterNumberTwo, parameterNumberThree, fourthParameter) { return g(parameterNumberOne); }",
+			"function f(\n  parameterNumberOne,\n  parameterNumberTwo,\n  parameterNumberThree,\n  fourthParameter\n) {\n  return g(parameterNumberOne);\n}\n",
+			format.DefaultOptions,
+		},
+		{
+			"let firstVariable = firstValue, secondVariable = secondValue, thirdVariable = value;",
+			"let firstVariable = firstValue,\n  secondVariable = secondValue,\n  thirdVariable = value;\n",
+			format.DefaultOptions,
+		},
+		{
+			"a = \"b\"; c = 'd'; e = 'it\"s'; f = \"it's\";",
+			"a = 'b';\nc = 'd';\ne = 'it\"s';\nf = \"it's\";\n",
+			format.Options{Indent: "\t", Quote: '\''},
+		},
+		{
+			"if (a) {\nb()\n}\nlet c = d;\n(e || f).g();\n-h;\ndo i; while (j);\nif (k) l; else m;",
+			"if (a) {\n  b()\n}\nlet c = d\n;(e || f).g()\n;-h\ndo i; while (j)\nif (k) l; else m\n",
+			noSemi,
+		},
+		{
+			"class C { static; a; async; b() {} }\ninterface;\nA;\ntype;\nB;",
+			"class C {\n  static;\n  a\n  async;\n  b() {}\n}\ninterface;\nA\ntype;\nB\n",
+			format.Options{Indent: "  ", Width: 80, Semicolons: printer.SemicolonsAsNeeded, Syntax: typescript},
+		},
+		{
+			"interface I { a: string; [k: string]: any; b(): void }\ntype T = { a: string }\nenum E { A, // a\n B }",
+			"interface I {\n  a: string;\n  [k: string]: any\n  b(): void\n}\ntype T = { a: string }\nenum E {\n  A, // a\n  B\n}\n",
+			format.Options{Indent: "  ", Width: 80, Semicolons: printer.SemicolonsAsNeeded, Syntax: typescript},
+		},
+		{
+			"type Point = { horizontalCoordinate: number; verticalCoordinate: number; label: string };",
+			"type Point = {\n  horizontalCoordinate: number;\n  verticalCoordinate: number;\n  label: string;\n};\n",
+			options(typescript),
+		},
+		{
+			"x = <Component firstAttribute=\"first value\" second={secondValue} third='x' fourth />;\ny = <a>{/* c */}</a>;",
+			"x = <Component\n  firstAttribute=\"first value\"\n  second={secondValue}\n  third='x'\n  fourth\n/>;\ny = <a>{/* c */}</a>;\n",
+			options(jsx),
+		},
+		{"", "", format.DefaultOptions},
+		{"// only\n", "// only\n", format.DefaultOptions},
+	}
+
+	for _, test := range tests {
+		actual, err := format.Source([]byte(test.src), test.options)
+		if err != nil {
+			t.Errorf("%q: %v", test.src, err)
+			continue
+		}
+
+		if string(actual) != test.expected {
+			t.Errorf("Unexpected formatting of %q.\nwant: %q\ngot:  %q", test.src, test.expected, actual)
+		}
+	}
+}
+
+var corpus = []struct {
+	src    string
+	syntax parser.Options
+}{
+	{`"use strict"; 'b'; ("c"); let a = 1, b; a = "x" + 2 * b; /* a */ // b`, parser.Options{}},
+	{"if (!a && b || null) { ; } else if (c) d; else while (true) do a = a - 1; while (false);\n\n\n// c", parser.Options{}},
+	{"for (let i = 0; i < 10; i += 1) /* i */ this.f[i](0); for (;;) {} // for", parser.Options{}},
+	{"function f(a, b) {\n\"use strict\";\n\nreturn a // a\n}\nfunction g() { return; }", parser.Options{}},
+	{"class A extends B { constructor() { super(); /* s */ } get x() { return 1; } set x(v) {} static y = 2 // y\n z\n static m() {} }", parser.Options{}},
+	{"x = class {}; (class {}).y; (a)\n(b)\n-c\n+d\n!e", parser.Options{}},
+	{"veryLongFunctionName(argumentNumberOne, nested(argumentNumberTwo, argumentNumberThree), four, five, six);", parser.Options{}},
+	{"@dec class A { @dec accessor x = 1; @dec.a.b(1) static m() {} @(x[0]) y; }", parser.Options{Decorators: true}},
+	{"<a.b c=\"d\" {...e} f:g={h} i=<j />>text  {/* */}{...i}<></>&amp; // not a comment\n {a /* b */}</a.b>; x = <><p:q /></>;", jsx},
+	{"<div>{// line\n}</div>;\n<VeryLongComponentName someAttribute={someValue} anotherAttribute=\"another value\">x</VeryLongComponentName>;", jsx},
+	{"interface I<T extends U = V> extends J<K>, L { readonly [k: string]: any; p?: unknown; // p\n m?<T>(a: number): void }", typescript},
+	{"type T = keyof A.B | C[] & D[\"e\"] | [string, boolean] | { x: null; y(): this } | -1 | (() => void);", typescript},
+	{"const enum E { A = 1, \"B\" } enum F {} let x!: string; x = y as const; x!; f<T>(); (a as T) < b;", typescript},
+	{"abstract class A<T> extends B<T> implements C<T>, D { constructor(private readonly a?: string, public b) {} abstract m(): void; r!: T; }", typescript},
+	{"type Long = { firstMember: string; secondMember: number; thirdMember: boolean; fourth: null };\ntype F = (a: T) => void", typescript},
+}
+
+func TestIdempotence(t *testing.T) {
+	for _, test := range corpus {
+		for _, options := range []format.Options{
+			options(test.syntax),
+			{Indent: "\t", Width: 40, Quote: '\'', Semicolons: printer.SemicolonsAsNeeded, Syntax: test.syntax},
+			{Indent: "    ", Width: 0, Syntax: test.syntax},
+		} {
+			formatted, err := format.Source([]byte(test.src), options)
+			if err != nil {
+				t.Errorf("%q: %v", test.src, err)
+				continue
+			}
+
+			again, err := format.Source(formatted, options)
+			if err != nil {
+				t.Errorf("%q: %v", formatted, err)
+				continue
+			}
+
+			if string(again) != string(formatted) {
+				t.Errorf("Formatting %q isn't idempotent.\nfirst: %s\nthen:  %s", test.src, formatted, again)
+			}
+
+			if strings.Count(string(formatted), "/*") != strings.Count(test.src, "/*") ||
+				strings.Count(string(formatted), "//") != strings.Count(test.src, "//") {
+				t.Errorf("Formatting %q lost comments:\n%s", test.src, formatted)
+			}
+
+			for _, line := range strings.Split(string(formatted), "\n") {
+				if strings.TrimRight(line, " \t") != line {
+					t.Errorf("Formatting %q left trailing whitespace:\n%s", test.src, formatted)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	_, err := format.Source([]byte("let = 1;"), format.DefaultOptions)
+
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 4 {
+		t.Errorf("Expected a syntax error at 4, got: %v", err)
+	}
+
+	if _, err := format.Source([]byte("let x: T;"), format.DefaultOptions); err == nil {
+		t.Errorf("Expected TypeScript to need the syntax option")
+	}
+}
+
+func ExampleSource() {
+	src := "let greeting='hello'   // greet\n\n\nfunction greet(name){return greeting+' '+name}"
+
+	formatted, err := format.Source([]byte(src), format.DefaultOptions)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(string(formatted))
+	// Output:
+	// let greeting = "hello"; // greet
+	//
+	// function greet(name) {
+	//   return greeting + " " + name;
+	// }
+}
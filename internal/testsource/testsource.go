@@ -0,0 +1,53 @@
+// Package testsource holds the programs the tests of the packages working on
+// whole trees share, to cover every node type with the syntax extensions
+// enabling them.
+package testsource
+
+import (
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+// Source is a program and the options parsing it.
+type Source struct {
+	Src     string
+	Options parser.Options
+}
+
+// Sources are valid programs using every node type the parser produces.
+var Sources = []Source{
+	{`"use strict"; 'b'; ("c"); let a = 1, b; a = "x" + 2 * b;`, parser.Options{}},
+	{`if (!a && b || null) { ; } else if (c) d; else while (true) do a = a - 1; while (false);`, parser.Options{}},
+	{`if (a) if (b) c; else d; if (a) { if (b) c; } else d; if (a) { while (b) if (c) d; } else e;`, parser.Options{}},
+	{`for (let i = 0; i < 10; i += 1) this.f[i](0); for (;;) {} for (let i = 0; ; ) ;`, parser.Options{}},
+	{`function f(a, b) { "use strict"; return a; } function g() { return 010; }`, parser.Options{}},
+	{`class A extends B { constructor() { super(); super.x(1)(2); } get x() { return 1; } set x(v) {} static y = 2; z; static m() {} }`, parser.Options{}},
+	{`x = class {}; (class {}).y; (class A {}).y = 1; x = class extends A { m() { return -(-a) + - -b - +c; } };`, parser.Options{}},
+	{`a = b = c; a += b; (a || b) && c; a || b && c; (a + b) * (c - d); a - (b - c); a - b - c; !(a < b) == c;`, parser.Options{}},
+	{`(f()).x; (f())[0]; (f(a)(b)).c.d(e); (1).x; (a + b)(); a.b.c[d](e);`, parser.Options{}},
+	{`c = class { m() {} };`, parser.Options{Locations: true, SourceFile: "a.js"}},
+	{`@dec class A { @dec accessor x = 1; @dec.a.b(1) static m() {} @(x[0]) y; }`, parser.Options{Decorators: true}},
+	{`<a.b c="d" {...e} f:g={h} i=<j />>text  {/* */}{...i}<></>&amp; </a.b>; x = <><p:q /></>;`, parser.Options{JSX: true}},
+	{`interface I<T extends U = V> extends J<K>, L { readonly [k: string]: any; p?: unknown; m?<T>(a: number): void; }`, parser.Options{TypeScript: true}},
+	{`type T = keyof A.B | C[] & D["e"] | [string, boolean] | { x: null; y(): this } | -1 | (() => void) | (keyof A)[] | (A | B)[];`, parser.Options{TypeScript: true}},
+	{`type F = <T>(a: T, b?: number) => T | null; type U = | A; type G = ((a) => void)[]; type H = A<B<C>>; type E = {};`, parser.Options{TypeScript: true}},
+	{`type K = object | bigint | symbol | undefined | never | this | 1;`, parser.Options{TypeScript: true}},
+	{`const enum E { A = 1, "B" } enum F {} let x!: string; x = y as const; x = y satisfies T; x!; f<T>(); (a as T) < b; a < b as T; (a as T) * 2;`, parser.Options{TypeScript: true}},
+	{`abstract class A<T> extends B<T> implements C<T>, D { constructor(private readonly a?: string, public b) {} abstract m(): void; protected abstract p: number; q?: string; r!: T; declare s; override readonly t = 1; }`, parser.Options{TypeScript: true}},
+	{`function f<T, U extends T>(a: T): (a: U) => void { return a!.b!; } let y: A.B.C<D>[] = (f<E>(1))!;`, parser.Options{TypeScript: true}},
+}
+
+// Parse parses a program, failing the test on syntax errors unless the
+// options are tolerant.
+func Parse(t testing.TB, src string, options parser.Options) parser.Node {
+	t.Helper()
+
+	n, err := parser.NewWithOptions(tokenizer.New(src), options).Parse()
+	if err != nil && !options.Tolerant {
+		t.Fatalf("%s: %v", src, err)
+	}
+
+	return n
+}
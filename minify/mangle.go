@@ -0,0 +1,310 @@
+package minify
+
+import (
+	"sort"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/transform"
+)
+
+// binding is a name declared in a scope.
+type binding struct {
+	scope *transform.Scope
+	name  string
+}
+
+// reference is an identifier naming a binding, or a global when to is nil.
+// from is the scope the identifier is in.
+type reference struct {
+	id       parser.Node
+	name     string
+	from, to *transform.Scope
+}
+
+// mangler collects the references of a tree and the names which can't
+// change.
+type mangler struct {
+	references []reference
+	scopes     map[*transform.Scope]bool
+	// fixed are the bindings keeping their names, and reserved the names no
+	// binding may be renamed to: globals and the names of fixed bindings.
+	fixed    map[binding]bool
+	reserved map[string]bool
+}
+
+// mangle renames the bindings of functions, blocks and 'for' statements.
+// Every binding takes the shortest name which neither a reserved name nor a
+// binding visible where it is used has. A direct 'eval' could use any name,
+// so the scopes around it keep theirs.
+func mangle(n parser.Node) {
+	m := &mangler{
+		scopes:   map[*transform.Scope]bool{},
+		fixed:    map[binding]bool{},
+		reserved: map[string]bool{},
+	}
+
+	transform.Traverse(n, transform.Callbacks{OnEnter: func(p *transform.NodePath) {
+		switch {
+		case p.Node.Is(parser.Identifier):
+			m.identifier(p)
+		case p.Node.Is(parser.JSXIdentifier):
+			m.jsxIdentifier(p)
+		}
+	}})
+
+	m.rename()
+}
+
+func (m *mangler) identifier(p *transform.NodePath) {
+	if p.Parent == nil || isPropertyName(p) {
+		return
+	}
+
+	name := parser.IdentifierNode(p.Node).Name()
+	from := p.Scope()
+	if p.Key == "id" && p.Parent.Node.Is(parser.FunctionDeclaration) {
+		// The name of a declared function belongs to the scope around it.
+		from = p.Parent.Parent.Scope()
+	}
+	to := lookup(from, name)
+	m.scopes[from] = true
+
+	switch {
+	case to == nil:
+		m.reserved[name] = true
+		if name == "eval" {
+			for scope := from; scope != nil; scope = scope.Parent {
+				for name := range scope.Bindings {
+					m.fix(binding{scope, name})
+				}
+			}
+		}
+	case p.Key == "id" && p.Parent.Node.Is(parser.ClassExpression),
+		p.Key == "parameter" && p.Parent.Node.Is(parser.TSParameterProperty):
+		// The scopes don't know the names of class expressions, and
+		// parameter properties name properties.
+		m.fix(binding{to, name})
+	case p.Key == "id" && p.Parent.Node.Is(parser.FunctionDeclaration) &&
+		to.Node.Is(parser.BlockStatement, parser.ForStatement) && !isStrict(p):
+		// Outside of strict code, functions declared in blocks are also
+		// visible in the function or the program around them.
+		m.fix(binding{to, name})
+	}
+
+	m.references = append(m.references, reference{p.Node, name, from, to})
+}
+
+// jsxIdentifier keeps the names of the bindings used as JSX element names,
+// whose case tells components from intrinsic elements.
+func (m *mangler) jsxIdentifier(p *transform.NodePath) {
+	switch {
+	case p.Parent == nil:
+		return
+	case p.Key == "name" && p.Parent.Node.Is(parser.JSXOpeningElement, parser.JSXClosingElement),
+		p.Key == "object" && p.Parent.Node.Is(parser.JSXMemberExpression):
+	default:
+		return
+	}
+
+	name := parser.JSXIdentifierNode(p.Node).Name()
+	if to := lookup(p.Scope(), name); to != nil {
+		m.fix(binding{to, name})
+	} else {
+		m.reserved[name] = true
+	}
+}
+
+func (m *mangler) fix(b binding) {
+	m.fixed[b] = true
+	m.reserved[b.name] = true
+}
+
+// lookup returns the scope declaring a name, or nil for the program's
+// bindings, which are globals.
+func lookup(from *transform.Scope, name string) *transform.Scope {
+	scope := from.Lookup(name)
+	if scope == nil || scope.Node.Is(parser.Program) {
+		return nil
+	}
+
+	return scope
+}
+
+// isStrict reports whether a node is in strict code: in a class, or in a
+// function or program starting with a "use strict" directive.
+func isStrict(p *transform.NodePath) bool {
+	for path := p; path != nil; path = path.Parent {
+		n := path.Node
+		switch {
+		case n.Is(parser.ClassBody):
+			return true
+		case n.Is(parser.Program):
+			return n["sourceType"] == "module" || hasUseStrict(n)
+		case n.Is(parser.FunctionDeclaration, parser.FunctionExpression):
+			if body, ok := n["body"].(parser.Node); ok && body != nil && hasUseStrict(body) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasUseStrict reports whether the directives of a body make it strict.
+func hasUseStrict(body parser.Node) bool {
+	for _, statement := range body["body"].([]parser.Node) {
+		directive, ok := statement["directive"].(string)
+		if !ok {
+			return false
+		}
+		if directive == "use strict" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPropertyName reports whether an identifier names a property, a member or
+// a type rather than a binding.
+func isPropertyName(p *transform.NodePath) bool {
+	parent := p.Parent.Node
+	computed, _ := parent["computed"].(bool)
+
+	switch p.Key {
+	case "property":
+		return parent.Is(parser.MemberExpression) && !computed
+	case "key":
+		return !computed
+	case "right":
+		return parent.Is(parser.TSQualifiedName)
+	case "id":
+		return parent.Is(parser.TSInterfaceDeclaration, parser.TSTypeAliasDeclaration,
+			parser.TSEnumDeclaration, parser.TSEnumMember)
+	case "name":
+		return parent.Is(parser.TSTypeParameter)
+	}
+
+	return false
+}
+
+// rename gives the bindings their new names, from the outermost scopes in.
+func (m *mangler) rename() {
+	// passing lists the bindings used in each scope but declared around it,
+	// whose names the scope's bindings would shadow.
+	passing := map[*transform.Scope][]binding{}
+	uses := map[binding]int{}
+	for _, r := range m.references {
+		if r.to == nil {
+			continue
+		}
+
+		b := binding{r.to, r.name}
+		uses[b]++
+		m.scopes[r.to] = true
+		for scope := r.from; scope != r.to; scope = scope.Parent {
+			passing[scope] = append(passing[scope], b)
+			m.scopes[scope] = true
+		}
+	}
+
+	scopes := []*transform.Scope{}
+	for scope := range m.scopes {
+		if scope.Node.Not(parser.Program) {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.SliceStable(scopes, func(i, j int) bool {
+		return depth(scopes[i]) < depth(scopes[j]) ||
+			depth(scopes[i]) == depth(scopes[j]) && scopes[i].Node.Start() < scopes[j].Node.Start()
+	})
+
+	names := map[binding]string{}
+	for _, scope := range scopes {
+		taken := map[string]bool{}
+		for _, b := range passing[scope] {
+			if name, ok := names[b]; ok {
+				taken[name] = true
+			} else {
+				taken[b.name] = true
+			}
+		}
+
+		// The most used bindings get the shortest names.
+		bindings := []binding{}
+		for name := range scope.Bindings {
+			bindings = append(bindings, binding{scope, name})
+		}
+		sort.Slice(bindings, func(i, j int) bool {
+			a, b := bindings[i], bindings[j]
+			if uses[a] != uses[b] {
+				return uses[a] > uses[b]
+			}
+
+			return scope.Bindings[a.name].Start() < scope.Bindings[b.name].Start()
+		})
+
+		next := 0
+		for _, b := range bindings {
+			if m.fixed[b] {
+				continue
+			}
+
+			for {
+				name := shortName(next)
+				next++
+				if !m.reserved[name] && !taken[name] && !keywords[name] {
+					names[b] = name
+					taken[name] = true
+					break
+				}
+			}
+		}
+	}
+
+	for _, r := range m.references {
+		if name, ok := names[binding{r.to, r.name}]; ok {
+			r.id["name"] = name
+		}
+	}
+}
+
+func depth(scope *transform.Scope) int {
+	d := 0
+	for ; scope.Parent != nil; scope = scope.Parent {
+		d++
+	}
+
+	return d
+}
+
+const (
+	firstChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ_$"
+	otherChars = firstChars + "0123456789"
+)
+
+// shortName returns the i-th shortest identifier.
+func shortName(i int) string {
+	name := []byte{firstChars[i%len(firstChars)]}
+	for i /= len(firstChars); i > 0; i /= len(otherChars) {
+		i--
+		name = append(name, otherChars[i%len(otherChars)])
+	}
+
+	return string(name)
+}
+
+// keywords are the words which can't name bindings, or shouldn't.
+var keywords = map[string]bool{}
+
+func init() {
+	for _, word := range []string{
+		"await", "break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete",
+		"do", "else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "import",
+		"in", "instanceof", "new", "null", "return", "super", "switch", "this", "throw", "true", "try",
+		"typeof", "var", "void", "while", "with", "yield", "let", "static", "implements", "interface",
+		"package", "private", "protected", "public", "arguments", "eval", "undefined", "NaN", "Infinity",
+	} {
+		keywords[word] = true
+	}
+}
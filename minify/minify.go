@@ -0,0 +1,215 @@
+// Package minify prints trees as small as it can, to ship code without a
+// JavaScript toolchain:
+//
+//	small, err := minify.Source(src, minify.DefaultOptions)
+//
+// The code is printed without the whitespace and the semicolons it doesn't
+// need, numbers and strings take their shortest forms, blocks holding a
+// single statement lose their braces, and the local bindings get short
+// names. Globals and property names are never renamed.
+package minify
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+	"github.com/0xvesion/go-js-parser/transform"
+)
+
+// Options configure the minifier.
+type Options struct {
+	// Mangle renames the bindings of functions, blocks and 'for' statements
+	// to short names. The bindings of the program are globals and keep
+	// their names.
+	Mangle bool
+	// Syntax enables the syntax extensions of the parser for Source.
+	Syntax parser.Options
+	// OnNode is passed to the printer, to map the minified code back to the
+	// source with the sourcemap package.
+	OnNode func(n parser.Node, offset int)
+}
+
+// DefaultOptions mangle names and parse plain JavaScript.
+var DefaultOptions = Options{Mangle: true}
+
+// Source minifies a program. Syntax errors are returned as the parser reports
+// them.
+func Source(src []byte, options Options) ([]byte, error) {
+	syntax := options.Syntax
+	syntax.Tolerant = false
+
+	program, err := parser.NewWithOptions(tokenizer.New(string(src)), syntax).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	minified, err := Print(program, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(minified), nil
+}
+
+// Print returns the minified code of a node, which is usually a Program.
+// The node is left as it is.
+func Print(n parser.Node, options Options) (string, error) {
+	n = simplify(parser.Clone(n))
+	if options.Mangle {
+		mangle(n)
+	}
+
+	return printer.Print(n, printer.Options{Compact: true, OnNode: options.OnNode})
+}
+
+// simplify shortens the literals and removes the braces and empty statements
+// the code doesn't need.
+func simplify(n parser.Node) parser.Node {
+	return transform.Traverse(n, transform.ByType{
+		parser.Literal: {OnEnter: func(p *transform.NodePath) {
+			// JSX attribute strings have no escapes to change quotes with.
+			if p.Parent == nil || p.Parent.Node.Not(parser.JSXAttribute) {
+				shortenLiteral(p.Node)
+			}
+		}},
+		parser.Program:        {OnLeave: flattenBody},
+		parser.BlockStatement: {OnLeave: flattenBody},
+		parser.IfStatement: {OnLeave: func(p *transform.NodePath) {
+			unwrapBody(p.Node, "consequent")
+			if alternate, ok := p.Node["alternate"].(parser.Node); ok && alternate != nil {
+				unwrapBody(p.Node, "alternate")
+			}
+		}},
+		parser.WhileStatement:   {OnLeave: unwrapLoopBody},
+		parser.DoWhileStatement: {OnLeave: unwrapLoopBody},
+		parser.ForStatement:     {OnLeave: unwrapLoopBody},
+	})
+}
+
+// shortenLiteral gives a number or a string its shortest source.
+func shortenLiteral(n parser.Node) {
+	switch value := n["value"].(type) {
+	case int:
+		n["raw"] = shortestNumber(value)
+	case string:
+		raw := shortestString(value)
+		n["raw"], n["value"] = raw, raw[1:len(raw)-1]
+	}
+}
+
+// shortestNumber returns the shortest source of an integer: its decimal
+// digits, without the leading zeros of legacy octals. Exponents would be
+// shorter for round numbers, but the tokenizer only reads digits.
+func shortestNumber(value int) string {
+	return strconv.Itoa(value)
+}
+
+// shortestString returns the shortest string literal for the source between
+// the quotes of a literal, which keeps its escapes. Double quotes win ties.
+func shortestString(content string) string {
+	shortest := ""
+	for _, quote := range []byte{'"', '\''} {
+		if s := requote(content, quote); shortest == "" || len(s) < len(shortest) {
+			shortest = s
+		}
+	}
+
+	return shortest
+}
+
+// requote quotes the source of a string with the given quote, escaping it and
+// unescaping the other one.
+func requote(content string, quote byte) string {
+	res := strings.Builder{}
+	res.WriteByte(quote)
+
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '\\' && i+1 < len(content):
+			i++
+			if next := content[i]; (next == '"' || next == '\'') && next != quote {
+				res.WriteByte(next)
+			} else {
+				res.WriteByte(c)
+				res.WriteByte(next)
+			}
+		case c == quote:
+			res.WriteByte('\\')
+			res.WriteByte(c)
+		default:
+			res.WriteByte(c)
+		}
+	}
+
+	res.WriteByte(quote)
+
+	return res.String()
+}
+
+// flattenBody removes the empty statements of a statement list, and puts the
+// statements of the blocks in it in their place when they declare nothing
+// but 'var's.
+func flattenBody(p *transform.NodePath) {
+	p.Node["body"] = flatten(p.Node["body"].([]parser.Node))
+}
+
+func flatten(statements []parser.Node) []parser.Node {
+	res := []parser.Node{}
+	for _, statement := range statements {
+		switch {
+		case statement.Is(parser.EmptyStatement):
+		case statement.Is(parser.BlockStatement) && !declaresLexically(statement):
+			res = append(res, flatten(statement["body"].([]parser.Node))...)
+		default:
+			res = append(res, statement)
+		}
+	}
+
+	return res
+}
+
+// declaresLexically reports whether a block declares names scoped to it.
+func declaresLexically(block parser.Node) bool {
+	for _, statement := range block["body"].([]parser.Node) {
+		if isLexicalDeclaration(statement) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isLexicalDeclaration(n parser.Node) bool {
+	switch n.Type() {
+	case parser.VariableDeclaration:
+		return n["kind"] != "var"
+	case parser.FunctionDeclaration, parser.ClassDeclaration,
+		parser.TSInterfaceDeclaration, parser.TSTypeAliasDeclaration, parser.TSEnumDeclaration:
+		return true
+	}
+
+	return false
+}
+
+func unwrapLoopBody(p *transform.NodePath) {
+	unwrapBody(p.Node, "body")
+}
+
+// unwrapBody replaces a block which is the body of a statement by its only
+// statement, or an empty one.
+func unwrapBody(n parser.Node, key string) {
+	body := n[key].(parser.Node)
+	if body.Not(parser.BlockStatement) {
+		return
+	}
+
+	switch statements := flatten(body["body"].([]parser.Node)); {
+	case len(statements) == 0:
+		n[key] = parser.NewEmptyStatement(body.Start(), body.End())
+	case len(statements) == 1 && !isLexicalDeclaration(statements[0]):
+		n[key] = statements[0]
+	}
+}
@@ -0,0 +1,188 @@
+package minify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/minify"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+var (
+	noMangle   = minify.Options{}
+	typescript = minify.Options{Mangle: true, Syntax: parser.Options{TypeScript: true}}
+	jsx        = minify.Options{Mangle: true, Syntax: parser.Options{JSX: true}}
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+		options  minify.Options
+	}{
+		{
+			"let a = 1000, b = 010, c = 100, d = 0;\nlet e = 'it\\'s', f = \"say \\\"hi\\\"\", g = 'plain\\n';",
+			`let a=1000,b=8,c=100,d=0;let e="it's",f='say "hi"',g="plain\n"`,
+			noMangle,
+		},
+		{
+			"if (a) { b(); } else { { c(); } } while (a) {} for (;;) { { let x; } } ;; { d; { e; } }",
+			"if(a)b();else c();while(a);for(;;){let x}d;e",
+			noMangle,
+		},
+		{
+			"if (a) { if (b) c(); } else d(); do { x; } while (y); { let z; }",
+			"if(a){if(b)c()}else d();do x;while(y);{let z}",
+			noMangle,
+		},
+		{
+			"function outer(first, second) { let local = first + second; function inner(value) { return value * local; } return inner(first); }",
+			"function outer(a,b){let c=a+b;function d(a){return a*c}return d(a)}",
+			minify.DefaultOptions,
+		},
+		{
+			"let a = 1; function f(b, c) { return a + b + c + console.log(c.length); }",
+			"let a=1;function f(c,b){return a+c+b+console.log(b.length)}",
+			minify.DefaultOptions,
+		},
+		{
+			"function f(value) { class Box { value = 1; read() { return this.value + value; } } return Box; }",
+			"function f(a){class b{value=1;read(){return this.value+a}}return b}",
+			minify.DefaultOptions,
+		},
+		{
+			"function f(outer) { for (let index = 0; index < outer; index = index + 1) { g(index, outer); } }",
+			"function f(a){for(let b=0;b<a;b=b+1)g(b,a)}",
+			minify.DefaultOptions,
+		},
+		{
+			"function f(code) { let kept = 1; eval(code); } function g(renamed) { return renamed; }",
+			"function f(code){let kept=1;eval(code)}function g(a){return a}",
+			minify.DefaultOptions,
+		},
+		{
+			"if (ready) { function helper() { return 1; } } helper();\nfunction f(x) { if (x) { function g() {} } return g(); }",
+			"if(ready){function helper(){return 1}}helper();function f(a){if(a){function g(){}}return g()}",
+			minify.DefaultOptions,
+		},
+		{
+			"function f(x) { \"use strict\"; if (x) { function g() {} g(); } }",
+			`function f(a){"use strict";if(a){function a(){}a()}}`,
+			minify.DefaultOptions,
+		},
+		{
+			"function f(props) { let Item = props.item; return <div title='a \"b\"'><Item value={props} /></div>; }",
+			`function f(a){let Item=a.item;return<div title='a "b"'><Item value={a}/></div>}`,
+			jsx,
+		},
+		{
+			"class A { constructor(private store: Store, other: number) {} m(key: string): string { let value: T = key; return value; } }",
+			"class A{constructor(private store:Store,a:number){}m(a:string):string{let b:T=a;return b}}",
+			typescript,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := minify.Source([]byte(test.src), test.options)
+		if err != nil {
+			t.Errorf("%q: %v", test.src, err)
+			continue
+		}
+
+		if string(actual) != test.expected {
+			t.Errorf("Unexpected minification of %q.\nwant: %s\ngot:  %s", test.src, test.expected, actual)
+		}
+	}
+}
+
+var corpus = []string{
+	`"use strict"; let a = 1, b; a = "x" + 2 * b; if (!a && b || null) { ; } else if (c) d; else while (true) do a = a - 1; while (false);`,
+	`function f(a, b) { "use strict"; { let a = b; g(a); } class C { m(c) { return a + c; } } return C; }`,
+	`class A extends B { constructor(x) { super(); super.x(x); } get x() { return 1; } set x(v) { this.v = v; } static y = 2; z; }`,
+	`function g(x, y) { while (x) { let y = x; x = y - 1; } for (let i = 0; i < y; i = i + 1) { for (let j = 0; ; ) { h(i, j, x); } } }`,
+	`function h(a) { let v = 1; if (a) { let w = v; v = w; } return v + h(a - 1); } x = (1).y + - -a + +b;`,
+	`let big = 1000, round = 25000000; f(big * round, (1000).toString);`,
+}
+
+var typescriptCorpus = []string{
+	`let y: A<B> = z; let w: Map<K, Set<V>> = m; function f<T>(a: T[]): Promise<T> { return a; }`,
+}
+
+func TestCorpus(t *testing.T) {
+	for _, src := range corpus {
+		roundTrip(t, src, minify.DefaultOptions)
+	}
+	for _, src := range typescriptCorpus {
+		roundTrip(t, src, typescript)
+	}
+}
+
+// roundTrip checks that the minified code of a source parses, and minifies
+// to itself.
+func roundTrip(t *testing.T, src string, options minify.Options) {
+	t.Helper()
+
+	minified, err := minify.Source([]byte(src), options)
+	if err != nil {
+		t.Errorf("%s: %v", src, err)
+		return
+	}
+
+	if _, err := parser.NewWithOptions(tokenizer.New(string(minified)), options.Syntax).Parse(); err != nil {
+		t.Errorf("Minifying %s gave invalid code: %v\n%s", src, err, minified)
+		return
+	}
+
+	again, err := minify.Source(minified, options)
+	if err != nil || string(again) != string(minified) {
+		t.Errorf("Minifying %s isn't stable.\nfirst: %s\nthen:  %s", src, minified, again)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	n, err := parser.New(tokenizer.New("function f(long) { { return long; } }")).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := parser.Clone(n)
+
+	minified, err := minify.Print(n, minify.DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if minified != "function f(a){return a}" {
+		t.Errorf("Unexpected output: %s", minified)
+	}
+
+	if !parser.Equal(n, clone, parser.EqualOptions{}) {
+		t.Errorf("Print changed the tree")
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	_, err := minify.Source([]byte("let = 1;"), minify.DefaultOptions)
+	if _, ok := err.(*parser.SyntaxError); !ok {
+		t.Errorf("Expected a syntax error, got: %v", err)
+	}
+}
+
+func ExampleSource() {
+	src := `function greet(name) {
+  let greeting = 'hello';
+  if (name) {
+    return greeting + ' ' + name;
+  }
+  return greeting;
+}`
+
+	minified, err := minify.Source([]byte(src), minify.DefaultOptions)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(minified))
+	// Output:
+	// function greet(a){let b="hello";if(a)return b+" "+a;return b}
+}
@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var knownTypes = map[Type]bool{}
+
+func init() {
+	for _, t := range Types {
+		knownTypes[t] = true
+	}
+}
+
+// DecodeJSON decodes an ESTree AST, as produced by acorn, Babel or by
+// marshalling a Node, into a Node. Node types are restored as Type,
+// integral numbers as int and children as Node and []Node, like the parser
+// creates them. Nulls are missing children, typed Node(nil), but for the
+// values of literals. Node types the parser doesn't know are rejected.
+func DecodeJSON(data []byte) (Node, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the node at %d", d.InputOffset())
+	}
+
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("want a node, got %T", v)
+	}
+
+	return decodeNode(object)
+}
+
+// UnmarshalJSON decodes a node with DecodeJSON, so a Node can be the target
+// of json.Unmarshal.
+func (n *Node) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	decoded, err := DecodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*n = decoded
+
+	return nil
+}
+
+func decodeNode(object map[string]interface{}) (Node, error) {
+	name, ok := object["type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("node without a type: %v", object["type"])
+	}
+
+	t := Type(name)
+	if !knownTypes[t] {
+		return nil, fmt.Errorf("unknown node type: %s", t)
+	}
+
+	n := Node{"type": t}
+	for key, value := range object {
+		var err error
+
+		switch key {
+		case "type":
+			continue
+		case "loc":
+			n[key], err = decodeLocation(value)
+		default:
+			if value == nil && (key != "value" || t != Literal) {
+				// Missing children are typed, like the parser leaves them.
+				n[key] = Node(nil)
+				continue
+			}
+			n[key], err = decodeValue(value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t, key, err)
+		}
+	}
+
+	for _, key := range []string{"start", "end"} {
+		if _, ok := n[key].(int); !ok {
+			return nil, fmt.Errorf("%s without an integer '%s'", t, key)
+		}
+	}
+
+	if kind, ok := n["kind"].(string); ok && n.Is(MethodDefinition, TSAbstractMethodDefinition, TSMethodSignature) {
+		n["kind"] = MethodDefinitionKind(kind)
+	}
+
+	return n, nil
+}
+
+func decodeValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return int(i), nil
+		}
+
+		return value.Float64()
+	case map[string]interface{}:
+		if _, ok := value["type"]; ok {
+			return decodeNode(value)
+		}
+
+		object := map[string]interface{}{}
+		for key, v := range value {
+			decoded, err := decodeValue(v)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = decoded
+		}
+
+		return object, nil
+	case []interface{}:
+		if isNodeList(value) {
+			list := make([]Node, len(value))
+			for i, v := range value {
+				if v == nil {
+					continue
+				}
+
+				child, err := decodeNode(v.(map[string]interface{}))
+				if err != nil {
+					return nil, err
+				}
+				list[i] = child
+			}
+
+			return list, nil
+		}
+
+		list := make([]interface{}, len(value))
+		for i, v := range value {
+			decoded, err := decodeValue(v)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = decoded
+		}
+
+		return list, nil
+	}
+
+	return value, nil
+}
+
+// isNodeList reports whether a list holds nodes, with nulls for holes. Empty
+// lists are node lists, as the parser creates them for every list field.
+func isNodeList(list []interface{}) bool {
+	for _, v := range list {
+		if v == nil {
+			continue
+		}
+
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := object["type"]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeLocation(value interface{}) (SourceLocation, error) {
+	loc := SourceLocation{}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return loc, err
+	}
+	if err := json.Unmarshal(b, &loc); err != nil {
+		return loc, fmt.Errorf("invalid location: %w", err)
+	}
+
+	return loc, nil
+}
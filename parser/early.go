@@ -0,0 +1,402 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+)
+
+var strictReservedWords = map[string]bool{
+	"implements": true,
+	"interface":  true,
+	"let":        true,
+	"package":    true,
+	"private":    true,
+	"protected":  true,
+	"public":     true,
+	"static":     true,
+	"yield":      true,
+}
+
+// strictContext tracks the state the early error rules depend on while
+// walking a tree: strictness, what the enclosing function allows and the names
+// declared in the current scope.
+type strictContext struct {
+	strict bool
+	// inFunction allows 'return'.
+	inFunction bool
+	// inMethod allows 'super.x'.
+	inMethod bool
+	// inDerivedConstructor allows 'super()'.
+	inDerivedConstructor bool
+
+	lexical map[string]bool
+	vars    map[string]bool
+	// functions are lexically declared functions, which sloppy code may
+	// redeclare inside blocks.
+	functions map[string]bool
+}
+
+func (c *strictContext) scope() *strictContext {
+	scope := *c
+	scope.lexical = map[string]bool{}
+	scope.vars = map[string]bool{}
+	scope.functions = map[string]bool{}
+
+	return &scope
+}
+
+type earlyChecker struct {
+	errors ErrorList
+}
+
+// earlyErrors walks a program and returns every early error in it, which are
+// the syntax errors the specification detects after parsing through the
+// "Static Semantics: Early Errors" rules.
+func earlyErrors(program Node) ErrorList {
+	c := &earlyChecker{}
+	ctx := (&strictContext{}).scope()
+	ctx.strict = hasUseStrict(program["body"].([]Node))
+
+	c.statements(ctx, program["body"].([]Node), true)
+
+	sort.SliceStable(c.errors, func(i, j int) bool {
+		return c.errors[i].Offset < c.errors[j].Offset
+	})
+
+	return c.errors
+}
+
+func (c *earlyChecker) raise(n Node, format string, args ...interface{}) {
+	c.errors = append(c.errors, &SyntaxError{Offset: n.Start(), End: n.End(), Message: fmt.Sprintf(format, args...)})
+}
+
+func hasUseStrict(body []Node) bool {
+	for _, statement := range body {
+		directive, ok := statement["directive"].(string)
+		if !ok {
+			return false
+		}
+
+		if directive == "use strict" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isLegacyOctal reports whether a numeric literal is written in the legacy
+// octal or leading-zero decimal form forbidden in strict code.
+func isLegacyOctal(raw string) bool {
+	if len(raw) < 2 || raw[0] != '0' {
+		return false
+	}
+
+	for _, r := range raw[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasOctalEscape reports whether a string literal contains a legacy octal
+// escape sequence (anything but \0 not followed by a digit).
+func hasOctalEscape(raw string) bool {
+	for i := 0; i < len(raw)-1; i++ {
+		if raw[i] != '\\' {
+			continue
+		}
+
+		next := raw[i+1]
+		if next >= '1' && next <= '9' {
+			return true
+		}
+		if next == '0' && i+2 < len(raw) && raw[i+2] >= '0' && raw[i+2] <= '9' {
+			return true
+		}
+
+		i++
+	}
+
+	return false
+}
+
+// statements declares the names bound by a statement list in ctx and checks
+// every statement. At the top level of a program or function, function
+// declarations are var scoped.
+func (c *earlyChecker) statements(ctx *strictContext, body []Node, topLevel bool) {
+	for _, statement := range body {
+		switch statement.Type() {
+		case VariableDeclaration:
+			for _, declarator := range statement["declarations"].([]Node) {
+				c.declareLexical(ctx, declarator["id"].(Node))
+			}
+		case ClassDeclaration:
+			c.declareLexical(ctx, statement["id"].(Node))
+		case FunctionDeclaration:
+			id := statement["id"].(Node)
+			if topLevel {
+				c.declareVar(ctx, id)
+			} else {
+				c.declareFunction(ctx, id)
+			}
+		}
+	}
+
+	for _, statement := range body {
+		c.node(ctx, statement)
+	}
+}
+
+func (c *earlyChecker) declareLexical(ctx *strictContext, id Node) {
+	name := IdentifierNode(id).Name()
+	if ctx.lexical[name] || ctx.vars[name] {
+		c.raise(id, "Identifier '%s' has already been declared", name)
+	}
+
+	ctx.lexical[name] = true
+}
+
+func (c *earlyChecker) declareFunction(ctx *strictContext, id Node) {
+	name := IdentifierNode(id).Name()
+	if !ctx.strict && ctx.functions[name] {
+		return
+	}
+
+	c.declareLexical(ctx, id)
+	ctx.functions[name] = true
+}
+
+func (c *earlyChecker) declareVar(ctx *strictContext, id Node) {
+	name := IdentifierNode(id).Name()
+	if ctx.lexical[name] {
+		c.raise(id, "Identifier '%s' has already been declared", name)
+	}
+
+	ctx.vars[name] = true
+}
+
+// binding checks an identifier which introduces a binding.
+func (c *earlyChecker) binding(ctx *strictContext, id Node) {
+	name := IdentifierNode(id).Name()
+	if ctx.strict && (name == "eval" || name == "arguments") {
+		c.raise(id, "Binding %s in strict mode", name)
+	}
+
+	c.reference(ctx, id)
+}
+
+// reference checks an identifier used as an expression.
+func (c *earlyChecker) reference(ctx *strictContext, id Node) {
+	name := IdentifierNode(id).Name()
+	if ctx.strict && strictReservedWords[name] {
+		c.raise(id, "The keyword '%s' is reserved", name)
+	}
+}
+
+func (c *earlyChecker) node(ctx *strictContext, n Node) {
+	if n == nil {
+		return
+	}
+
+	switch n.Type() {
+	case BlockStatement:
+		c.statements(ctx.scope(), n["body"].([]Node), false)
+	case VariableDeclaration:
+		for _, declarator := range n["declarations"].([]Node) {
+			id := declarator["id"].(Node)
+			if IdentifierNode(id).Name() == "let" {
+				c.raise(id, "let is disallowed as a lexically bound name")
+			} else {
+				c.binding(ctx, id)
+			}
+
+			c.node(ctx, declarator["init"].(Node))
+		}
+	case ForStatement:
+		scope := ctx.scope()
+		if init := n["init"].(Node); init != nil && init.Is(VariableDeclaration) {
+			c.statements(scope, []Node{init}, false)
+		} else {
+			c.node(scope, init)
+		}
+		c.node(scope, n["test"].(Node))
+		c.node(scope, n["update"].(Node))
+		c.node(scope, n["body"].(Node))
+	case FunctionDeclaration, FunctionExpression:
+		c.function(ctx, n, false, false)
+	case ClassDeclaration, ClassExpression:
+		c.class(ctx, n)
+	case ReturnStatement:
+		if !ctx.inFunction {
+			c.raise(n, "'return' outside of function")
+		}
+		c.node(ctx, n["argument"].(Node))
+	case CallExpression:
+		callee := n["callee"].(Node)
+		if callee.Is(SuperExpression) {
+			if !ctx.inDerivedConstructor {
+				c.raise(callee, "super() call outside constructor of a subclass")
+			}
+		} else {
+			c.node(ctx, callee)
+		}
+		for _, argument := range n["arguments"].([]Node) {
+			c.node(ctx, argument)
+		}
+	case MemberExpression:
+		object := n["object"].(Node)
+		if object.Is(SuperExpression) {
+			if !ctx.inMethod {
+				c.raise(object, "'super' keyword outside a method")
+			}
+		} else {
+			c.node(ctx, object)
+		}
+		if n["computed"].(bool) {
+			c.node(ctx, n["property"].(Node))
+		}
+	case SuperExpression:
+		c.raise(n, "'super' keyword outside a method")
+	case AssignmentExpression:
+		left := n["left"].(Node)
+		if left.Is(Identifier) {
+			name := IdentifierNode(left).Name()
+			if ctx.strict && (name == "eval" || name == "arguments") {
+				c.raise(left, "Assigning to %s in strict mode", name)
+			}
+		}
+		c.node(ctx, left)
+		c.node(ctx, n["right"].(Node))
+	case Identifier:
+		c.reference(ctx, n)
+	case JSXAttribute:
+		// JSX strings have no escape sequences.
+		if value, ok := n["value"].(Node); ok && value != nil && value.Not(Literal) {
+			c.node(ctx, value)
+		}
+	case Literal:
+		raw := LiteralNode(n).Raw()
+		if !ctx.strict {
+			break
+		}
+		if _, ok := LiteralNode(n).Value().(string); ok && hasOctalEscape(raw) {
+			c.raise(n, "Octal literal in strict mode")
+		} else if isLegacyOctal(raw) {
+			c.raise(n, "Octal literal in strict mode")
+		}
+	default:
+		c.children(ctx, n)
+	}
+}
+
+// children checks every child node of n.
+func (c *earlyChecker) children(ctx *strictContext, n Node) {
+	keys := make([]string, 0, len(n))
+	for k := range n {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		switch child := n[k].(type) {
+		case Node:
+			c.node(ctx, child)
+		case []Node:
+			for _, v := range child {
+				c.node(ctx, v)
+			}
+		}
+	}
+}
+
+// function checks a function declaration or expression. Methods always
+// require unique parameter names.
+func (c *earlyChecker) function(ctx *strictContext, n Node, method bool, derivedConstructor bool) {
+	// TypeScript method signatures have no body.
+	var statements []Node
+	if body, ok := n["body"].(Node); ok && body != nil {
+		statements = body["body"].([]Node)
+	}
+
+	scope := ctx.scope()
+	scope.strict = ctx.strict || hasUseStrict(statements)
+	scope.inFunction = true
+	scope.inMethod = method
+	scope.inDerivedConstructor = derivedConstructor
+
+	if id, ok := n["id"].(Node); ok && id != nil {
+		c.binding(scope, id)
+	}
+
+	for _, param := range n["params"].([]Node) {
+		if param.Is(TSParameterProperty) {
+			param = param["parameter"].(Node)
+		}
+
+		name := IdentifierNode(param).Name()
+		if scope.vars[name] && (scope.strict || method) {
+			c.raise(param, "Argument name clash")
+		}
+
+		c.binding(scope, param)
+		scope.vars[name] = true
+	}
+
+	c.statements(scope, statements, true)
+}
+
+// class checks a class, whose code is always strict.
+func (c *earlyChecker) class(ctx *strictContext, n Node) {
+	scope := *ctx
+	scope.strict = true
+
+	if id, ok := n["id"].(Node); ok && id != nil {
+		c.binding(&scope, id)
+	}
+
+	c.decorators(&scope, n)
+
+	superClass, _ := n["superClass"].(Node)
+	c.node(&scope, superClass)
+
+	hasConstructor := false
+	for _, member := range n["body"].(Node)["body"].([]Node) {
+		key := member["key"].(Node)
+		isConstructor := key.Is(Identifier) && IdentifierNode(key).Name() == "constructor"
+		c.decorators(&scope, member)
+
+		if member.Is(PropertyDefinition, AccessorProperty, TSAbstractPropertyDefinition) {
+			if isConstructor {
+				c.raise(key, "Classes can't have a field named 'constructor'")
+			}
+
+			fieldScope := scope
+			fieldScope.inMethod = true
+			c.node(&fieldScope, member["value"].(Node))
+
+			continue
+		}
+
+		kind := member["kind"].(MethodDefinitionKind)
+		if kind == ConstructorMethod {
+			if hasConstructor {
+				c.raise(key, "Duplicate constructor in the same class")
+			}
+			hasConstructor = true
+		}
+
+		derived := kind == ConstructorMethod && superClass != nil
+		c.function(&scope, member["value"].(Node), true, derived)
+	}
+}
+
+// decorators checks the decorators of a class or class member.
+func (c *earlyChecker) decorators(ctx *strictContext, n Node) {
+	decorators, _ := n["decorators"].([]Node)
+	for _, decorator := range decorators {
+		c.node(ctx, decorator)
+	}
+}
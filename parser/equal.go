@@ -0,0 +1,267 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// EqualOptions choose the keys Equal and Hash ignore, to compare code rather
+// than where it is.
+type EqualOptions struct {
+	// IgnorePositions ignores 'start', 'end' and 'range'.
+	IgnorePositions bool
+	// IgnoreLocations ignores 'loc'.
+	IgnoreLocations bool
+	// IgnoreRaw ignores 'raw', so 0x10 equals 16. The values of literals
+	// are still compared.
+	IgnoreRaw bool
+	// IgnoreComments ignores the comments attached to nodes by other
+	// parsers, such as Babel's 'leadingComments'.
+	IgnoreComments bool
+}
+
+// CodeOptions ignore everything but the code.
+var CodeOptions = EqualOptions{IgnorePositions: true, IgnoreLocations: true, IgnoreRaw: true, IgnoreComments: true}
+
+func (o EqualOptions) ignores(key string) bool {
+	switch key {
+	case "start", "end", "range":
+		return o.IgnorePositions
+	case "loc":
+		return o.IgnoreLocations
+	case "raw":
+		return o.IgnoreRaw
+	case "comments", "leadingComments", "trailingComments", "innerComments":
+		return o.IgnoreComments
+	}
+
+	return false
+}
+
+// Clone returns a deep copy of a node, sharing nothing with it.
+func Clone(n Node) Node {
+	if n == nil {
+		return nil
+	}
+
+	return cloneValue(n).(Node)
+}
+
+func cloneValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case Node:
+		if v == nil {
+			return v
+		}
+
+		n := make(Node, len(v))
+		for key, value := range v {
+			n[key] = cloneValue(value)
+		}
+
+		return n
+	case []Node:
+		list := make([]Node, len(v))
+		for i, child := range v {
+			list[i] = cloneValue(child).(Node)
+		}
+
+		return list
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			object[key] = cloneValue(value)
+		}
+
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, value := range v {
+			list[i] = cloneValue(value)
+		}
+
+		return list
+	}
+
+	return v
+}
+
+// Equal reports whether two nodes are structurally equal. Missing keys,
+// null and empty lists are equal, as are numbers of different Go types and
+// strings of different string types, such as Type and string.
+func Equal(a Node, b Node, options EqualOptions) bool {
+	return equalValues(a, b, options)
+}
+
+func equalValues(a interface{}, b interface{}, options EqualOptions) bool {
+	a, b = normalize(a), normalize(b)
+
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key := range a {
+			if !options.ignores(key) && !equalValues(a[key], b[key], options) {
+				return false
+			}
+		}
+		for key := range b {
+			if _, ok := a[key]; !ok && !options.ignores(key) && normalize(b[key]) != nil {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !equalValues(a[i], b[i], options) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if _, ok := b.(map[string]interface{}); ok {
+		return false
+	}
+	if _, ok := b.([]interface{}); ok {
+		return false
+	}
+
+	return a == b
+}
+
+// normalize maps the values of a node to a few types: nil for absent values,
+// map[string]interface{} for objects, []interface{} for non-empty lists,
+// float64, string and bool, and comparable values, such as SourceLocation,
+// for the rest.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case Node:
+		if v == nil {
+			return nil
+		}
+
+		return map[string]interface{}(v)
+	case map[string]interface{}:
+		return v
+	case []Node:
+		if len(v) == 0 {
+			return nil
+		}
+
+		list := make([]interface{}, len(v))
+		for i, child := range v {
+			list[i] = child
+		}
+
+		return list
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	}
+
+	if !rv.Type().Comparable() {
+		return fmt.Sprintf("%#v", v)
+	}
+
+	return v
+}
+
+// Hash returns a structural hash of a node, equal for nodes which are Equal
+// with the same options. It doesn't depend on the order of map keys, so it is
+// stable across runs.
+func Hash(n Node, options EqualOptions) uint64 {
+	h := fnv.New64a()
+	hashValue(h, n, options)
+
+	return h.Sum64()
+}
+
+func hashValue(h hash.Hash64, v interface{}, options EqualOptions) {
+	switch v := normalize(v).(type) {
+	case nil:
+		h.Write([]byte{0})
+	case map[string]interface{}:
+		keys := []string{}
+		for key, value := range v {
+			if !options.ignores(key) && normalize(value) != nil {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		h.Write([]byte{1})
+		for _, key := range keys {
+			hashString(h, key)
+			hashValue(h, v[key], options)
+		}
+		h.Write([]byte{2})
+	case []interface{}:
+		h.Write([]byte{3})
+		for _, value := range v {
+			hashValue(h, value, options)
+		}
+		h.Write([]byte{4})
+	case float64:
+		if v == 0 {
+			// -0 equals 0.
+			v = 0
+		}
+
+		h.Write([]byte{5})
+		binary.Write(h, binary.LittleEndian, math.Float64bits(v))
+	case string:
+		h.Write([]byte{6})
+		hashString(h, v)
+	case bool:
+		if v {
+			h.Write([]byte{7})
+		} else {
+			h.Write([]byte{8})
+		}
+	default:
+		h.Write([]byte{9})
+		hashString(h, fmt.Sprintf("%#v", v))
+	}
+}
+
+// hashString writes the length before the string, so "ab", "c" and "a", "bc"
+// hash differently.
+func hashString(h hash.Hash64, s string) {
+	binary.Write(h, binary.LittleEndian, uint64(len(s)))
+	h.Write([]byte(s))
+}
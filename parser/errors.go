@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+// SyntaxError is the error returned by Parse for invalid sources. Offsets are
+// counted in the encoding of the tokenizer.
+type SyntaxError struct {
+	Offset int
+	// End is the offset after the code the error is about: the found token,
+	// or the node of an early error. It is Offset when unknown.
+	End int
+	// Line is 1-based and Column is 0-based.
+	Line   int
+	Column int
+	// Expected are the token types which would have been valid, if known.
+	Expected []tokenizer.Type
+	// Found is the token at which parsing failed. It is empty for early
+	// errors and invalid tokens.
+	Found   tokenizer.Token
+	Message string
+	// Err is the error the syntax error comes from, such as the
+	// *tokenizer.LexError of an invalid token, or nil.
+	Err error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s (%d:%d)", e.Message, e.Line, e.Column)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorList is the error returned by Parse when one or more early errors
+// were found. The errors are sorted by position.
+type ErrorList []*SyntaxError
+
+func (l ErrorList) Error() string {
+	messages := make([]string, len(l))
+	for i, err := range l {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors of the list, for errors.Is and errors.As.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// InternalError is the error Parse returns when the parser fails on a bug of
+// its own, such as an index out of range, rather than on the source. It is
+// worth reporting with the source.
+type InternalError struct {
+	// Offset is the offset of the token the parser was at.
+	Offset int
+	// Err is the recovered runtime error.
+	Err error
+	// Stack is the stack trace of the failure.
+	Stack []byte
+}
+
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("internal parser error at %d: %v", e.Offset, e.Err)
+}
+
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+// syntaxError converts a value recovered from the grammar to a SyntaxError.
+// Errors without a position are reported at the lookahead.
+func (p *parser) syntaxError(r any) *SyntaxError {
+	err, ok := r.(*SyntaxError)
+	if !ok {
+		err = &SyntaxError{
+			Offset:  p.offset(p.lookAhead),
+			Found:   p.lookAhead,
+			Message: fmt.Sprint(r),
+		}
+	}
+
+	p.locate(err)
+
+	return err
+}
+
+func isRuntimeError(r any) bool {
+	_, ok := r.(runtime.Error)
+
+	return ok
+}
+
+// offset returns the offset of a token, placing the end of input token at the
+// end of the source.
+func (p *parser) offset(token tokenizer.Token) int {
+	if token.Is(tokenizer.None) {
+		return p.t.Encoding().FromBytes(p.t.Src(), len(p.t.Src()))
+	}
+
+	return token.Start
+}
+
+// locate sets the line and column of an error from its offset, and its end
+// when it has none.
+func (p *parser) locate(err *SyntaxError) {
+	if err.End <= err.Offset {
+		err.End = err.Offset
+		if err.Found.Start == err.Offset && err.Found.End > err.End {
+			err.End = err.Found.End
+		}
+	}
+
+	position := p.lines().Position(err.Offset)
+	err.Line = position.Line
+	err.Column = position.Column
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/0xvesion/go-js-parser/tokenizer"
 )
@@ -15,7 +16,9 @@ func (p *parser) program() Node {
 	sl := p.statementList(tokenizer.None)
 	sl = p.addDirectives(sl)
 
-	return NewProgram(0, len(p.t.Src()), sl...)
+	end := p.t.Encoding().FromBytes(p.t.Src(), len(p.t.Src()))
+
+	return NewProgram(0, end, sl...)
 }
 
 // StatementList
@@ -25,8 +28,8 @@ func (p *parser) program() Node {
 func (p *parser) statementList(endLookahead tokenizer.Type) []Node {
 	sl := []Node{}
 
-	for p.lookAhead.Not(endLookahead) {
-		sl = append(sl, p.statement())
+	for p.lookAhead.Not(endLookahead, tokenizer.None) {
+		sl = append(sl, p.statementOrError())
 	}
 
 	return sl
@@ -42,14 +45,22 @@ func (p *parser) statementList(endLookahead tokenizer.Type) []Node {
 // 	| FunctionDeclaration
 // 	| ReturnStatement
 // 	| ClassDeclaration
+// 	| TypeScriptDeclaration
 // 	;
 func (p *parser) statement() Node {
+	p.enter()
+	defer p.leave()
+
 	switch p.lookAhead.Type {
 	case tokenizer.OpeningCurlyBrace:
 		return p.blockStatement()
 	case tokenizer.Semicolon:
 		return p.emptyStatement()
 	case tokenizer.VariableDeclarationKeyword:
+		if p.options.TypeScript && p.lookAhead.Value == "const" && p.peek().Value == "enum" {
+			return p.tsEnumDeclaration()
+		}
+
 		return p.variableDeclaration()
 	case tokenizer.IfKeyword:
 		return p.ifStatement()
@@ -63,30 +74,141 @@ func (p *parser) statement() Node {
 		return p.functionDeclaration()
 	case tokenizer.ReturnKeyword:
 		return p.returnStatement()
-	case tokenizer.ClassKeyword:
+	case tokenizer.ClassKeyword, tokenizer.At:
 		return p.classDeclaration()
-	default:
-		return p.expressionStatment()
 	}
+
+	p.rejectLetDeclaration()
+
+	if p.options.TypeScript {
+		if declaration := p.tsDeclaration(); declaration != nil {
+			return declaration
+		}
+	}
+
+	return p.expressionStatment()
 }
 
 // ClassDeclaration
-// 	: 'class' Identifier ClassBody
-// 	| 'class' Identifier 'extends' Identifier ClassBody
+// 	: OptDecoratorList 'class' Identifier OptTypeParameters OptClassHeritage ClassBody
+// 	;
+//
+// ClassHeritage
+// 	: 'extends' Identifier OptTypeArguments OptImplements
+// 	| Implements
+// 	;
+//
+// Implements
+// 	: 'implements' HeritageList
 // 	;
 func (p *parser) classDeclaration() Node {
-	start := p.consume(tokenizer.ClassKeyword).Start
+	return p.class(NewClassDeclaration, false)
+}
+
+// ClassExpression
+// 	: OptDecoratorList 'class' OptIdentifier OptTypeParameters OptClassHeritage ClassBody
+// 	;
+func (p *parser) classExpression() Node {
+	return p.class(NewClassExpression, true)
+}
+
+func (p *parser) class(newer func(int, int, Node, Node, Node) Node, optionalId bool) Node {
+	start := p.lookAhead.Start
+	decorators := p.optDecoratorList()
+	p.consume(tokenizer.ClassKeyword)
+
+	var id Node
+	if !optionalId || p.lookAhead.Is(tokenizer.Identifier) && !p.isLookaheadValue(tokenizer.Identifier, "implements") {
+		id = p.identifier()
+	}
+	typeParameters := p.tsOptTypeParameters()
 
-	id := p.identifier()
 	var superClass Node
+	var superTypeArguments Node
 	if p.lookAhead.Is(tokenizer.ExtendsKeyword) {
 		p.consume(tokenizer.ExtendsKeyword)
 		superClass = p.identifier()
+
+		if p.options.TypeScript && p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
+			superTypeArguments = p.tsTypeArguments()
+		}
+	}
+
+	var implements []Node
+	if p.options.TypeScript && p.isLookaheadValue(tokenizer.Identifier, "implements") {
+		p.consume(tokenizer.Identifier)
+		implements = p.tsHeritageList(NewTSClassImplements)
 	}
 
 	body := p.classBody()
 
-	return NewClassDeclaration(start, body.End(), id, superClass, body)
+	class := newer(start, body.End(), id, superClass, body)
+	setOptionalList(class, "decorators", decorators)
+	setOptional(class, "typeParameters", typeParameters)
+	setOptional(class, "superTypeArguments", superTypeArguments)
+	setOptionalList(class, "implements", implements)
+
+	return class
+}
+
+// DecoratorList
+// 	: Decorator
+// 	| DecoratorList Decorator
+// 	;
+func (p *parser) optDecoratorList() []Node {
+	decorators := []Node{}
+	if !p.options.Decorators {
+		return decorators
+	}
+
+	for p.lookAhead.Is(tokenizer.At) {
+		decorators = append(decorators, p.decorator())
+	}
+
+	return decorators
+}
+
+// Decorator
+// 	: '@' DecoratorMemberExpression
+// 	| '@' DecoratorMemberExpression '(' OptArgumentList ')'
+// 	| '@' ParenthesizedExpression
+// 	;
+//
+// DecoratorMemberExpression
+// 	: Identifier
+// 	| DecoratorMemberExpression '.' Identifier
+// 	;
+func (p *parser) decorator() Node {
+	start := p.consume(tokenizer.At).Start
+
+	if p.lookAhead.Is(tokenizer.OpeningParenthesis) {
+		p.consume(tokenizer.OpeningParenthesis)
+		expression := p.expression()
+		end := p.consume(tokenizer.ClosingParenthesis).End
+
+		return NewDecorator(start, end, expression)
+	}
+
+	expression := p.identifier()
+	for p.lookAhead.Is(tokenizer.Dot) {
+		p.consume(tokenizer.Dot)
+		property := p.identifier()
+
+		expression = NewMemberExpression(expression.Start(), property.End(), expression, property, false)
+	}
+
+	if p.lookAhead.Is(tokenizer.OpeningParenthesis) {
+		p.consume(tokenizer.OpeningParenthesis)
+		arguments := []Node{}
+		if p.lookAhead.Not(tokenizer.ClosingParenthesis) {
+			arguments = p.argumentList()
+		}
+		end := p.consume(tokenizer.ClosingParenthesis).End
+
+		expression = NewCallExpression(expression.Start(), end, expression, arguments)
+	}
+
+	return NewDecorator(start, expression.End(), expression)
 }
 
 // ClassBody
@@ -106,24 +228,32 @@ func (p *parser) classBody() Node {
 }
 
 // ClassMemberDefinition
-// 	: NewPropertyDefinition
-// 	| NewMethodDefinition
+// 	: OptClassMemberModifierList NewPropertyDefinition
+// 	| OptClassMemberModifierList NewMethodDefinition
 // 	;
 func (p *parser) classMemberDefinition() Node {
-	prefixes := []tokenizer.Token{}
+	start := p.lookAhead.Start
+	decorators := p.optDecoratorList()
+	modifiers := p.classMemberModifiers()
 
-	for p.lookAhead.Not(Identifier) {
+	prefixes := []tokenizer.Token{}
+	for p.lookAhead.Not(Identifier, tokenizer.None) {
 		prefixes = append(prefixes, p.consumeAny())
 	}
 
 	key := p.identifier()
-	start := key.Start()
 	hasPrefix := len(prefixes) > 0
-	if hasPrefix {
-		start = prefixes[0].Start
+
+	var optional, definite bool
+	if p.options.TypeScript && p.lookAhead.Is(tokenizer.QuestionMark) {
+		p.consume(tokenizer.QuestionMark)
+		optional = true
+	} else if p.options.TypeScript && p.lookAhead.Is(tokenizer.LogicalNotOperator) {
+		p.consume(tokenizer.LogicalNotOperator)
+		definite = true
 	}
 
-	if p.lookAhead.Is(tokenizer.OpeningParenthesis) {
+	if p.lookAhead.Is(tokenizer.OpeningParenthesis) || p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
 		value := p.functionExpression()
 
 		kind := Method
@@ -138,9 +268,23 @@ func (p *parser) classMemberDefinition() Node {
 			}
 		}
 
-		return NewMethodDefinition(start, value.End(), key, kind, value)
+		end := value.End()
+		if value.Is(TSEmptyBodyFunctionExpression) {
+			end = p.semicolon()
+		}
+
+		method := NewMethodDefinition(start, end, key, kind, value)
+		setOptionalList(method, "decorators", decorators)
+		if optional {
+			method["optional"] = true
+		}
+
+		return p.applyClassMemberModifiers(method, modifiers)
 	}
 
+	p.requireEcmaVersion(2022, "class field")
+	typeAnnotation := p.tsOptTypeAnnotation()
+
 	var value Node
 	if p.lookAhead.Is(tokenizer.SimpleAssignmentOperator) {
 		p.consume(tokenizer.SimpleAssignmentOperator)
@@ -148,24 +292,122 @@ func (p *parser) classMemberDefinition() Node {
 		value = p.expression()
 	}
 
-	end := p.consume(tokenizer.Semicolon).End
-	return NewPropertyDefinition(start, end, key, value)
+	end := p.semicolon()
+	property := NewPropertyDefinition(start, end, key, value)
+	setOptionalList(property, "decorators", decorators)
+	setOptional(property, "typeAnnotation", typeAnnotation)
+	if optional {
+		property["optional"] = true
+	}
+	if definite {
+		property["definite"] = true
+	}
+
+	return p.applyClassMemberModifiers(property, modifiers)
+}
+
+// ClassMemberModifierList
+// 	: ClassMemberModifier
+// 	| ClassMemberModifierList ClassMemberModifier
+// 	;
+//
+// ClassMemberModifier
+// 	: 'static'
+// 	| 'accessor'
+// 	| TS_MODIFIER
+// 	;
+//
+// A modifier followed by anything but a name is the name of the member.
+func (p *parser) classMemberModifiers() []string {
+	modifiers := []string{}
+
+	for p.isLookaheadClassMemberModifier() {
+		next := p.peek()
+		if next.Not(tokenizer.Identifier, tokenizer.GetKeyword, tokenizer.SetKeyword) {
+			break
+		}
+
+		modifiers = append(modifiers, p.consume(tokenizer.Identifier).Value)
+	}
+
+	return modifiers
+}
+
+func (p *parser) isLookaheadClassMemberModifier() bool {
+	if p.lookAhead.Not(tokenizer.Identifier) {
+		return false
+	}
+
+	switch p.lookAhead.Value {
+	case "static":
+		return true
+	case "accessor":
+		return p.options.Decorators
+	case "public", "private", "protected", "readonly", "abstract", "override", "declare":
+		return p.options.TypeScript
+	}
+
+	return false
+}
+
+func (p *parser) applyClassMemberModifiers(member Node, modifiers []string) Node {
+	for _, modifier := range modifiers {
+		switch modifier {
+		case "static":
+			member["static"] = true
+		case "public", "private", "protected":
+			member["accessibility"] = modifier
+		case "accessor":
+			if member.Is(MethodDefinition) {
+				panic(fmt.Errorf("'accessor' modifier cannot be used on a method"))
+			}
+			member["type"] = Type(AccessorProperty)
+		case "abstract":
+			if member.Is(MethodDefinition) {
+				member["type"] = Type(TSAbstractMethodDefinition)
+			} else {
+				member["type"] = Type(TSAbstractPropertyDefinition)
+			}
+		default:
+			member[modifier] = true
+		}
+	}
+
+	return member
 }
 
 // FunctionExpression
-// 	: '(' OptParameterList ')' BlockStatement
+// 	: OptTypeParameters '(' OptParameterList ')' OptTypeAnnotation FunctionBody
 // 	;
+//
+// In TypeScript the body of a method signature may be missing.
 func (p *parser) functionExpression() Node {
-	start := p.consume(tokenizer.OpeningParenthesis).Start
+	start := p.lookAhead.Start
+	typeParameters := p.tsOptTypeParameters()
+
+	p.consume(tokenizer.OpeningParenthesis)
 	params := []Node{}
 	if p.lookAhead.Not(tokenizer.ClosingParenthesis) {
 		params = p.parameterList()
 	}
-	p.consume(tokenizer.ClosingParenthesis)
+	end := p.consume(tokenizer.ClosingParenthesis).End
 
-	body := p.blockStatement()
+	returnType := p.tsOptTypeAnnotation()
+	if p.options.TypeScript && p.lookAhead.Not(tokenizer.OpeningCurlyBrace) {
+		if returnType != nil {
+			end = returnType.End()
+		}
+
+		return NewTSEmptyBodyFunctionExpression(start, end, typeParameters, params, returnType)
+	}
 
-	return NewFunctionExpression(start, body.End(), params, body)
+	body := p.functionBody()
+
+	function := NewFunctionExpression(start, body.End(), params, body)
+	setOptional(function, "typeParameters", typeParameters)
+	setOptional(function, "returnType", returnType)
+
+	return function
 }
 
 // ReturnStatement
@@ -175,22 +417,23 @@ func (p *parser) returnStatement() Node {
 	start := p.consume(tokenizer.ReturnKeyword).Start
 
 	var argument Node
-	if p.lookAhead.Not(tokenizer.Semicolon) {
+	if p.lookAhead.Not(tokenizer.Semicolon) && !p.canInsertSemicolon() {
 		argument = p.expression()
 	}
 
-	end := p.consume(tokenizer.Semicolon).End
+	end := p.semicolon()
 
 	return NewReturnStatement(start, end, argument)
 }
 
 // FunctionDeclaration
-// 	: 'function' Identifier '(' OptParameterList ')' BlockStatement
+// 	: 'function' BindingIdentifier OptTypeParameters '(' OptParameterList ')' OptTypeAnnotation FunctionBody
 // 	;
 func (p *parser) functionDeclaration() Node {
 	start := p.consume(tokenizer.FunctionKeyword).Start
 
-	id := p.identifier()
+	id := p.bindingIdentifier()
+	typeParameters := p.tsOptTypeParameters()
 
 	p.consume(tokenizer.OpeningParenthesis)
 	params := []Node{}
@@ -199,26 +442,54 @@ func (p *parser) functionDeclaration() Node {
 	}
 	p.consume(tokenizer.ClosingParenthesis)
 
+	returnType := p.tsOptTypeAnnotation()
+
+	body := p.functionBody()
+
+	function := NewFunctionDeclaration(start, body.End(), id, params, body)
+	setOptional(function, "typeParameters", typeParameters)
+	setOptional(function, "returnType", returnType)
+
+	return function
+}
+
+// FunctionBody
+// 	: BlockStatement
+// 	;
+func (p *parser) functionBody() Node {
 	body := p.blockStatement()
+	p.addDirectives(body["body"].([]Node))
 
-	return NewFunctionDeclaration(start, body.End(), id, params, body)
+	return body
 }
 
 // ParameterList
-// 	: Identifier
-//	| ParameterList ',' Identifier
+// 	: Parameter
+//	| ParameterList ',' Parameter
 // 	;
 func (p *parser) parameterList() []Node {
-	ids := []Node{p.identifier()}
+	ids := []Node{p.parameter()}
 
 	for p.lookAhead.Is(tokenizer.Comma) {
 		p.consume(tokenizer.Comma)
-		ids = append(ids, p.identifier())
+		ids = append(ids, p.parameter())
 	}
 
 	return ids
 }
 
+// Parameter
+// 	: BindingIdentifier OptQuestionMark OptTypeAnnotation
+// 	| ParameterProperty
+// 	;
+func (p *parser) parameter() Node {
+	if p.isLookaheadParameterModifier() {
+		return p.tsParameterProperty()
+	}
+
+	return p.tsAnnotateBinding(p.bindingIdentifier())
+}
+
 // IfStatement
 // 	: 'if' ParenthesizedExpression Statement
 // 	| 'if' ParenthesizedExpression Statement 'else' Statement
@@ -268,6 +539,7 @@ func (p *parser) forStatement() Node {
 
 	var init Node
 	if !p.lookAhead.Is(tokenizer.Semicolon) {
+		p.rejectLetDeclaration()
 		init = p.variableDeclarationInit()
 	}
 	p.consume(tokenizer.Semicolon)
@@ -290,8 +562,11 @@ func (p *parser) forStatement() Node {
 }
 
 // DoWhileStatement
-//	: 'do' Statement 'while' ParenthesizedExpression ';'
+//	: 'do' Statement 'while' ParenthesizedExpression OptSemicolon
 // 	;
+//
+// Since ES2015, the ';' of a do-while statement is inserted even before code
+// on the same line.
 func (p *parser) doWhileStatement() Node {
 	start := p.consume(tokenizer.DoKeyword).Start
 
@@ -301,7 +576,15 @@ func (p *parser) doWhileStatement() Node {
 
 	test := p.parenthesizedExpression()
 
-	end := p.consume(tokenizer.Semicolon).End
+	var end int
+	switch {
+	case p.lookAhead.Is(tokenizer.Semicolon):
+		end = p.consume(tokenizer.Semicolon).End
+	case p.options.EcmaVersion >= 2015:
+		end = p.lookBehind.End
+	default:
+		end = p.semicolon()
+	}
 
 	return NewDoWhileStatement(start, end, test, body)
 }
@@ -330,12 +613,25 @@ func (p *parser) variableDeclarationInit() Node {
 	return NewVariableDeclaration(kind.Start, end, kind.Value, declarations)
 }
 
+// rejectLetDeclaration fails on a 'let' declaration before ES2015, where
+// 'let' is an identifier and 'let x' isn't valid code.
+func (p *parser) rejectLetDeclaration() {
+	if !p.isLookaheadValue(tokenizer.Identifier, "let") {
+		return
+	}
+
+	next := p.peek()
+	if next.Is(tokenizer.Identifier) && p.lines().Position(p.lookAhead.End).Line == p.lines().Position(next.Start).Line {
+		p.requireEcmaVersion(2015, "'let'")
+	}
+}
+
 // VariableDeclaration
 // 	: VariableDeclarationInit ';'
 // 	;
 func (p *parser) variableDeclaration() Node {
 	init := p.variableDeclarationInit()
-	end := p.consume(tokenizer.Semicolon).End
+	end := p.semicolon()
 	init.SetEnd(end)
 
 	return init
@@ -357,20 +653,35 @@ func (p *parser) variableDeclaratorList() []Node {
 }
 
 // VariableDeclarator
-// 	: Identifier OptVariableInitializer
+// 	: BindingIdentifier OptDefinite OptTypeAnnotation OptVariableInitializer
 // 	;
 func (p *parser) variableDeclarator() Node {
-	id := p.identifier()
+	id := p.bindingIdentifier()
+
+	definite := p.isLookaheadNonNull()
+	if definite {
+		p.consume(tokenizer.LogicalNotOperator)
+	}
+
+	if typeAnnotation := p.tsOptTypeAnnotation(); typeAnnotation != nil {
+		id["typeAnnotation"] = typeAnnotation
+		id.SetEnd(typeAnnotation.End())
+	}
 
 	var init Node
 	end := id.End()
-	if p.lookAhead.Not(tokenizer.Semicolon, tokenizer.Comma) {
+	if p.lookAhead.Is(tokenizer.SimpleAssignmentOperator) || p.lookAhead.Not(tokenizer.Semicolon, tokenizer.Comma) && !p.canInsertSemicolon() {
 		p.consume(tokenizer.SimpleAssignmentOperator)
 		init = p.assignmentExpression()
 		end = init.End()
 	}
 
-	return NewVariableDeclarator(id.Start(), end, id, init)
+	declarator := NewVariableDeclarator(id.Start(), end, id, init)
+	if definite {
+		declarator["definite"] = true
+	}
+
+	return declarator
 }
 
 // EmptyStatement
@@ -402,9 +713,9 @@ func (p *parser) expressionStatment() Node {
 	start := p.lookAhead.Start
 	exp := p.expression()
 
-	semi := p.consume(tokenizer.Semicolon)
+	end := p.semicolon()
 
-	return NewExpressionStatement(start, semi.End, exp)
+	return NewExpressionStatement(start, end, exp)
 }
 
 // Expression
@@ -419,6 +730,9 @@ func (p *parser) expression() Node {
 // 	| LeftHandSideExpression ASSIGNMENT_OPERATOR AssignmentExpression
 // 	;
 func (p *parser) assignmentExpression() Node {
+	p.enter()
+	defer p.leave()
+
 	left := p.logicalOrExpression()
 
 	if !p.isLookaheadAssignmentOperator() {
@@ -474,13 +788,21 @@ func (p *parser) equalityExpression() Node {
 // RelationalExpression
 // 	: AdditiveExpression
 // 	| RelationalExpression RELATIONAL_OPERATOR AdditiveExpression
+// 	| RelationalExpression TypeAssertion
 // 	;
 func (p *parser) relationalExpression() Node {
-	return p.binaryExpression(
+	start := p.lookAhead.Start
+	expression := p.binaryExpression(
 		p.additiveExpression,
 		tokenizer.RelationalOperator,
 		NewBinaryExpression,
 	)
+
+	for p.isLookaheadTypeAssertion() {
+		expression = p.tsTypeAssertion(start, expression)
+	}
+
+	return expression
 }
 
 // AdditiveExpression
@@ -513,10 +835,15 @@ func (p *parser) multiplicativeExpression() Node {
 //	| LOGICAL_NOT UnaryExpression
 // 	;
 func (p *parser) unaryExpression() Node {
+	p.enter()
+	defer p.leave()
+
 	if p.lookAhead.Not(tokenizer.LogicalNotOperator, tokenizer.AdditiveOperator) {
 		return p.leftHandSideExpression()
 	}
 
+	p.rejectUpdateOperator()
+
 	operator := p.consumeAny()
 	expr := p.unaryExpression()
 	return NewUnaryExpression(
@@ -527,6 +854,21 @@ func (p *parser) unaryExpression() Node {
 	)
 }
 
+// rejectUpdateOperator fails on a '+' or '-' right after the same operator.
+// The tokenizer has no '++' and '--', which would otherwise be read as two
+// additions, even across a line where a ';' is inserted before them.
+func (p *parser) rejectUpdateOperator() {
+	previous := p.lookBehind
+	if p.lookAhead.Is(tokenizer.AdditiveOperator) && previous.Is(tokenizer.AdditiveOperator) &&
+		previous.Value == p.lookAhead.Value && previous.End == p.lookAhead.Start {
+		panic(&SyntaxError{
+			Offset:  previous.Start,
+			End:     p.lookAhead.End,
+			Message: fmt.Sprintf("'%s%s' is not supported", previous.Value, p.lookAhead.Value),
+		})
+	}
+}
+
 // LeftHandSideExpression
 // 	: CallExpression
 //	;
@@ -536,12 +878,25 @@ func (p *parser) leftHandSideExpression() Node {
 
 // CallExpression
 // 	: MemberExpression
-//  | CallExpression '(' OptArgumentList ')'
+//  | CallExpression OptTypeArguments '(' OptArgumentList ')'
+//  | CallExpression '!'
 //	;
 func (p *parser) callExpression() Node {
 	callee := p.memberExpression()
 
-	for p.lookAhead.Is(tokenizer.OpeningParenthesis) {
+	for {
+		if p.isLookaheadNonNull() {
+			end := p.consume(tokenizer.LogicalNotOperator).End
+			callee = NewTSNonNullExpression(callee.Start(), end, callee)
+
+			continue
+		}
+
+		typeArguments := p.tsOptCallTypeArguments()
+		if p.lookAhead.Not(tokenizer.OpeningParenthesis) {
+			return callee
+		}
+
 		p.consume(tokenizer.OpeningParenthesis)
 		arguments := []Node{}
 		if p.lookAhead.Not(tokenizer.ClosingParenthesis) {
@@ -550,9 +905,8 @@ func (p *parser) callExpression() Node {
 		end := p.consume(tokenizer.ClosingParenthesis).End
 
 		callee = NewCallExpression(callee.Start(), end, callee, arguments)
+		setOptional(callee, "typeArguments", typeArguments)
 	}
-
-	return callee
 }
 
 // ArgumentList
@@ -573,12 +927,16 @@ func (p *parser) argumentList() []Node {
 // 	: PrimaryExpression
 // 	| MemberExpression '.' Identifier
 // 	| MemberExpression '[' Expression ']'
+// 	| MemberExpression '!'
 //	;
 func (p *parser) memberExpression() Node {
 	object := p.primaryExpression()
 
-	for p.lookAhead.Is(tokenizer.Dot, tokenizer.OpeningBracket) {
-		if p.lookAhead.Is(tokenizer.Dot) {
+	for p.lookAhead.Is(tokenizer.Dot, tokenizer.OpeningBracket) || p.isLookaheadNonNull() {
+		if p.isLookaheadNonNull() {
+			end := p.consume(tokenizer.LogicalNotOperator).End
+			object = NewTSNonNullExpression(object.Start(), end, object)
+		} else if p.lookAhead.Is(tokenizer.Dot) {
 			p.consume(tokenizer.Dot)
 			property := p.identifier()
 
@@ -602,6 +960,8 @@ func (p *parser) memberExpression() Node {
 //	| ThisExpression
 //  | ParenthesizedExpression
 //  | Identifier
+//  | JSXElement
+//  | ClassExpression
 // 	;
 func (p *parser) primaryExpression() Node {
 	if p.isLookaheadLiteral() {
@@ -617,9 +977,22 @@ func (p *parser) primaryExpression() Node {
 		return p.parenthesizedExpression()
 	case tokenizer.Identifier:
 		return p.identifier()
-	default:
-		panic(fmt.Errorf("invalid token: %s", p.lookAhead.Type))
+	case tokenizer.ClassKeyword, tokenizer.At:
+		return p.classExpression()
+	case tokenizer.RelationalOperator:
+		if p.options.JSX && p.lookAhead.Value == "<" {
+			return p.jsxElement()
+		}
+	}
+
+	err := fmt.Errorf("invalid token: %s", p.lookAhead.Type)
+	if p.options.Tolerant && p.isLookaheadExpressionEnd() {
+		p.recordError(p.syntaxError(err))
+
+		return NewIdentifier(p.lookAhead.Start, p.lookAhead.Start, "")
 	}
+
+	panic(err)
 }
 
 // SuperExpression
@@ -649,6 +1022,23 @@ func (p *parser) identifier() Node {
 	return NewIdentifier(id.Start, id.End, id.Value)
 }
 
+// BindingIdentifier
+// 	: Identifier
+// 	| 'let'
+// 	;
+//
+// 'let' is only rejected by the early errors, which know whether the binding
+// is lexical.
+func (p *parser) bindingIdentifier() Node {
+	if p.lookAhead.Is(tokenizer.VariableDeclarationKeyword) && p.lookAhead.Value == "let" {
+		id := p.consumeAny()
+
+		return NewIdentifier(id.Start, id.End, id.Value)
+	}
+
+	return p.identifier()
+}
+
 // ParenthesizedExpression
 // 	: '(' Expression ')'
 // 	;
@@ -708,12 +1098,24 @@ func (p *parser) nullLiteral() Node {
 func (p *parser) numericLiteral() Node {
 	token := p.consume(tokenizer.Number)
 
-	value, err := strconv.Atoi(token.Value)
+	// Leading zeros make a legacy octal, unless a digit is 8 or 9.
+	base := 10
+	if isLegacyOctal(token.Value) && !strings.ContainsAny(token.Value, "89") {
+		base = 8
+	}
+
+	value, err := strconv.ParseInt(token.Value, base, 0)
 	if err != nil {
-		panic(err)
+		panic(&SyntaxError{
+			Offset:  token.Start,
+			End:     token.End,
+			Found:   token,
+			Message: fmt.Sprintf("number out of range: %s", token.Value),
+			Err:     err,
+		})
 	}
 
-	return NewLiteral(token.Start, token.End, value, token.Value)
+	return NewLiteral(token.Start, token.End, int(value), token.Value)
 }
 
 // StringLiteral
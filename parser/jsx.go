@@ -0,0 +1,295 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+// JSXElement
+// 	: '<' JSXElementAfterOpen
+// 	;
+//
+// The tokenizer context is switched before consuming the token that precedes
+// a tag or children, so the following token is scanned with JSX rules.
+func (p *parser) jsxElement() Node {
+	start := p.lookAhead.Start
+	p.t.SetContext(tokenizer.JSXTagContext)
+	p.consume(tokenizer.RelationalOperator)
+
+	return p.jsxElementAfterOpen(start, tokenizer.DefaultContext)
+}
+
+// JSXElementAfterOpen
+// 	: JSXElementName OptJSXAttributeList '/' '>'
+// 	| JSXElementName OptJSXAttributeList '>' OptJSXChildren '<' '/' JSXElementName '>'
+// 	| JSXFragment
+// 	;
+func (p *parser) jsxElementAfterOpen(start int, after tokenizer.Context) Node {
+	p.enter()
+	defer p.leave()
+
+	if p.lookAhead.Is(tokenizer.JSXTagEnd) {
+		return p.jsxFragment(start, after)
+	}
+
+	name := p.jsxElementName()
+
+	attributes := []Node{}
+	for p.lookAhead.Not(tokenizer.MultiplicativeOperator, tokenizer.JSXTagEnd) {
+		attributes = append(attributes, p.jsxAttribute())
+	}
+
+	if p.lookAhead.Is(tokenizer.MultiplicativeOperator) {
+		p.consume(tokenizer.MultiplicativeOperator)
+		p.t.SetContext(after)
+		end := p.consume(tokenizer.JSXTagEnd).End
+
+		opening := NewJSXOpeningElement(start, end, name, attributes, true)
+
+		return NewJSXElement(start, end, opening, nil, []Node{})
+	}
+
+	p.t.SetContext(tokenizer.JSXChildContext)
+	openingEnd := p.consume(tokenizer.JSXTagEnd).End
+	opening := NewJSXOpeningElement(start, openingEnd, name, attributes, false)
+
+	children := p.jsxChildren()
+
+	closingStart := p.lookBehind.Start
+	p.consume(tokenizer.MultiplicativeOperator)
+	closingName := p.jsxElementName()
+	if jsxName(closingName) != jsxName(name) {
+		panic(fmt.Errorf("expected corresponding JSX closing tag for <%s>", jsxName(name)))
+	}
+	p.t.SetContext(after)
+	end := p.consume(tokenizer.JSXTagEnd).End
+
+	closing := NewJSXClosingElement(closingStart, end, closingName)
+
+	return NewJSXElement(start, end, opening, closing, children)
+}
+
+// JSXFragment
+// 	: '>' OptJSXChildren '<' '/' '>'
+// 	;
+func (p *parser) jsxFragment(start int, after tokenizer.Context) Node {
+	p.t.SetContext(tokenizer.JSXChildContext)
+	openingEnd := p.consume(tokenizer.JSXTagEnd).End
+	opening := NewJSXOpeningFragment(start, openingEnd)
+
+	children := p.jsxChildren()
+
+	closingStart := p.lookBehind.Start
+	p.consume(tokenizer.MultiplicativeOperator)
+	p.t.SetContext(after)
+	end := p.consume(tokenizer.JSXTagEnd).End
+
+	closing := NewJSXClosingFragment(closingStart, end)
+
+	return NewJSXFragment(start, end, opening, closing, children)
+}
+
+// JSXChildren
+// 	: JSXChild
+// 	| JSXChildren JSXChild
+// 	;
+//
+// JSXChild
+// 	: JSXText
+// 	| JSXExpressionContainer
+// 	| '<' JSXElementAfterOpen
+// 	;
+//
+// The children end once the '<' of the closing tag has been consumed.
+func (p *parser) jsxChildren() []Node {
+	children := []Node{}
+
+	for {
+		switch p.lookAhead.Type {
+		case tokenizer.JSXText:
+			children = append(children, p.jsxText())
+		case tokenizer.OpeningCurlyBrace:
+			children = append(children, p.jsxExpressionContainer(tokenizer.JSXChildContext, true))
+		default:
+			start := p.lookAhead.Start
+			p.t.SetContext(tokenizer.JSXTagContext)
+			p.consume(tokenizer.JSXTagStart)
+
+			if p.lookAhead.Is(tokenizer.MultiplicativeOperator) {
+				return children
+			}
+
+			children = append(children, p.jsxElementAfterOpen(start, tokenizer.JSXChildContext))
+		}
+	}
+}
+
+// JSXElementName
+// 	: JSXIdentifier
+// 	| JSXNamespacedName
+// 	| JSXMemberExpression
+// 	;
+//
+// JSXMemberExpression
+// 	: JSXIdentifier '.' JSXIdentifier
+// 	| JSXMemberExpression '.' JSXIdentifier
+// 	;
+func (p *parser) jsxElementName() Node {
+	name := p.jsxIdentifier()
+
+	if p.lookAhead.Is(tokenizer.Colon) {
+		return p.jsxNamespacedName(name)
+	}
+
+	for p.lookAhead.Is(tokenizer.Dot) {
+		p.consume(tokenizer.Dot)
+		property := p.jsxIdentifier()
+
+		name = NewJSXMemberExpression(name.Start(), property.End(), name, property)
+	}
+
+	return name
+}
+
+// JSXNamespacedName
+// 	: JSXIdentifier ':' JSXIdentifier
+// 	;
+func (p *parser) jsxNamespacedName(namespace Node) Node {
+	p.consume(tokenizer.Colon)
+	name := p.jsxIdentifier()
+
+	return NewJSXNamespacedName(namespace.Start(), name.End(), namespace, name)
+}
+
+// JSXAttribute
+// 	: '{' '...' AssignmentExpression '}'
+// 	| JSXAttributeName
+// 	| JSXAttributeName '=' JSXAttributeValue
+// 	;
+//
+// JSXAttributeName
+// 	: JSXIdentifier
+// 	| JSXNamespacedName
+// 	;
+func (p *parser) jsxAttribute() Node {
+	if p.lookAhead.Is(tokenizer.OpeningCurlyBrace) {
+		start := p.lookAhead.Start
+		p.t.SetContext(tokenizer.DefaultContext)
+		p.consume(tokenizer.OpeningCurlyBrace)
+		p.consume(tokenizer.Spread)
+
+		argument := p.assignmentExpression()
+
+		p.t.SetContext(tokenizer.JSXTagContext)
+		end := p.consume(tokenizer.ClosingCurlyBrace).End
+
+		return NewJSXSpreadAttribute(start, end, argument)
+	}
+
+	name := p.jsxIdentifier()
+	if p.lookAhead.Is(tokenizer.Colon) {
+		name = p.jsxNamespacedName(name)
+	}
+
+	if p.lookAhead.Not(tokenizer.SimpleAssignmentOperator) {
+		return NewJSXAttribute(name.Start(), name.End(), name, nil)
+	}
+
+	p.consume(tokenizer.SimpleAssignmentOperator)
+	value := p.jsxAttributeValue()
+
+	return NewJSXAttribute(name.Start(), value.End(), name, value)
+}
+
+// JSXAttributeValue
+// 	: STRING
+// 	| JSXExpressionContainer
+// 	| '<' JSXElementAfterOpen
+// 	;
+func (p *parser) jsxAttributeValue() Node {
+	switch p.lookAhead.Type {
+	case tokenizer.String:
+		token := p.consume(tokenizer.String)
+		value := tokenizer.DecodeEntities(token.Value[1 : len(token.Value)-1])
+
+		return NewLiteral(token.Start, token.End, value, token.Value)
+	case tokenizer.OpeningCurlyBrace:
+		return p.jsxExpressionContainer(tokenizer.JSXTagContext, false)
+	case tokenizer.JSXTagStart:
+		start := p.consume(tokenizer.JSXTagStart).Start
+
+		return p.jsxElementAfterOpen(start, tokenizer.JSXTagContext)
+	}
+
+	panic(fmt.Errorf("invalid JSX attribute value: %s", p.lookAhead.Type))
+}
+
+// JSXExpressionContainer
+// 	: '{' OptExpression '}'
+// 	| '{' '...' Expression '}'
+// 	;
+//
+// Only children may be empty or spread.
+func (p *parser) jsxExpressionContainer(after tokenizer.Context, child bool) Node {
+	p.t.SetContext(tokenizer.DefaultContext)
+	brace := p.consume(tokenizer.OpeningCurlyBrace)
+
+	spread := child && p.lookAhead.Is(tokenizer.Spread)
+	if spread {
+		p.consume(tokenizer.Spread)
+	}
+
+	var expression Node
+	if p.lookAhead.Is(tokenizer.ClosingCurlyBrace) && !spread {
+		if !child {
+			panic(fmt.Errorf("JSX attributes must only be assigned a non-empty expression"))
+		}
+
+		expression = NewJSXEmptyExpression(brace.End, p.lookAhead.Start)
+	} else {
+		expression = p.expression()
+	}
+
+	p.t.SetContext(after)
+	end := p.consume(tokenizer.ClosingCurlyBrace).End
+
+	if spread {
+		return NewJSXSpreadChild(brace.Start, end, expression)
+	}
+
+	return NewJSXExpressionContainer(brace.Start, end, expression)
+}
+
+// JSXText
+// 	: JSX_TEXT
+// 	;
+func (p *parser) jsxText() Node {
+	token := p.consume(tokenizer.JSXText)
+	value := tokenizer.DecodeEntities(strings.ReplaceAll(token.Value, "\r\n", "\n"))
+
+	return NewJSXText(token.Start, token.End, value, token.Value)
+}
+
+// JSXIdentifier
+// 	: JSX_IDENTIFIER
+// 	;
+func (p *parser) jsxIdentifier() Node {
+	token := p.consume(tokenizer.JSXIdentifier)
+
+	return NewJSXIdentifier(token.Start, token.End, token.Value)
+}
+
+// jsxName returns the qualified name of an element name, used to match
+// opening and closing tags.
+func jsxName(n Node) string {
+	switch n.Type() {
+	case JSXNamespacedName:
+		return jsxName(n["namespace"].(Node)) + ":" + jsxName(n["name"].(Node))
+	case JSXMemberExpression:
+		return jsxName(n["object"].(Node)) + "." + jsxName(n["property"].(Node))
+	}
+
+	return JSXIdentifierNode(n).Name()
+}
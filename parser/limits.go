@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is wrapped by the error Parse returns when the source
+// exceeds one of the limits of the Options.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// CanceledError is the error ParseContext returns when its context is done
+// before the end of the source.
+type CanceledError struct {
+	// Offset is where parsing stopped.
+	Offset int
+	// Err is the error of the context.
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("parsing stopped at %d: %v", e.Offset, e.Err)
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultMaxDepth is the nesting depth allowed when Options.MaxDepth is zero.
+// It keeps deeply nested input from overflowing the stack.
+const DefaultMaxDepth = 1000
+
+// cancelCheckInterval is the number of tokens read between checks of the
+// context, which is cheap but not free.
+const cancelCheckInterval = 256
+
+// enter is called by every recursive production, which defers leave.
+func (p *parser) enter() {
+	p.depth++
+	if p.depth > p.options.MaxDepth {
+		panic(fmt.Errorf("%w: nesting depth exceeds %d", ErrLimitExceeded, p.options.MaxDepth))
+	}
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) countToken() {
+	p.tokens++
+	if p.tokens%cancelCheckInterval == 1 {
+		p.checkContext()
+	}
+	if p.options.MaxTokens > 0 && p.tokens > p.options.MaxTokens {
+		panic(fmt.Errorf("%w: token count exceeds %d", ErrLimitExceeded, p.options.MaxTokens))
+	}
+}
+
+// checkContext stops parsing once the context of ParseContext is done.
+func (p *parser) checkContext() {
+	if err := p.ctx.Err(); err != nil {
+		panic(&CanceledError{Offset: p.lookBehind.End, Err: err})
+	}
+}
+
+// isAbort reports whether a recovered value is a limit or cancellation
+// error, or a runtime error of the parser, which neither backtracking nor
+// tolerant mode recover from.
+func isAbort(r any) bool {
+	err, ok := r.(error)
+	var canceled *CanceledError
+
+	return ok && (errors.Is(err, ErrLimitExceeded) || errors.As(err, &canceled)) || isRuntimeError(r)
+}
@@ -0,0 +1,43 @@
+package parser
+
+import "github.com/0xvesion/go-js-parser/tokenizer"
+
+// SourceLocation is the 'loc' of a node.
+type SourceLocation struct {
+	Start  tokenizer.Position `json:"start"`
+	End    tokenizer.Position `json:"end"`
+	Source string             `json:"source,omitempty"`
+}
+
+// lines returns the line index of the source, building it on first use.
+func (p *parser) lines() *tokenizer.LineIndex {
+	if p.lineIndex == nil {
+		p.lineIndex = tokenizer.NewLineIndex(p.t.Src(), p.t.Encoding())
+	}
+
+	return p.lineIndex
+}
+
+// addLocations sets the 'loc' of n and every node below it.
+func (p *parser) addLocations(n Node) {
+	if n == nil {
+		return
+	}
+
+	n["loc"] = SourceLocation{
+		Start:  p.lines().Position(n.Start()),
+		End:    p.lines().Position(n.End()),
+		Source: p.options.SourceFile,
+	}
+
+	for _, child := range n {
+		switch child := child.(type) {
+		case Node:
+			p.addLocations(child)
+		case []Node:
+			for _, v := range child {
+				p.addLocations(v)
+			}
+		}
+	}
+}
@@ -3,35 +3,194 @@ package parser
 type Type string
 
 const (
-	Program              Type = "Program"
-	Literal                   = "Literal"
-	ExpressionStatement       = "ExpressionStatement"
-	BlockStatement            = "BlockStatement"
-	EmptyStatement            = "EmptyStatement"
-	BinaryExpression          = "BinaryExpression"
-	AssignmentExpression      = "AssignmentExpression"
-	Identifier                = "Identifier"
-	VariableDeclaration       = "VariableDeclaration"
-	VariableDeclarator        = "VariableDeclarator"
-	IfStatement               = "IfStatement"
-	LogicalExpression         = "LogicalExpression"
-	UnaryExpression           = "UnaryExpression"
-	WhileStatement            = "WhileStatement"
-	ForStatement              = "ForStatement"
-	DoWhileStatement          = "DoWhileStatement"
-	FunctionDeclaration       = "FunctionDeclaration"
-	ReturnStatement           = "ReturnStatement"
-	MemberExpression          = "MemberExpression"
-	CallExpression            = "CallExpression"
-	ClassDeclaration          = "ClassDeclaration"
-	ClassBody                 = "ClassBody"
-	PropertyDefinition        = "PropertyDefinition"
-	MethodDefinition          = "MethodDefinition"
-	FunctionExpression        = "FunctionExpression"
-	SuperExpression           = "Super"
-	ThisExpression            = "ThisExpression"
+	Program                       Type = "Program"
+	Literal                            = "Literal"
+	ExpressionStatement                = "ExpressionStatement"
+	BlockStatement                     = "BlockStatement"
+	EmptyStatement                     = "EmptyStatement"
+	BinaryExpression                   = "BinaryExpression"
+	AssignmentExpression               = "AssignmentExpression"
+	Identifier                         = "Identifier"
+	VariableDeclaration                = "VariableDeclaration"
+	VariableDeclarator                 = "VariableDeclarator"
+	IfStatement                        = "IfStatement"
+	LogicalExpression                  = "LogicalExpression"
+	UnaryExpression                    = "UnaryExpression"
+	WhileStatement                     = "WhileStatement"
+	ForStatement                       = "ForStatement"
+	DoWhileStatement                   = "DoWhileStatement"
+	FunctionDeclaration                = "FunctionDeclaration"
+	ReturnStatement                    = "ReturnStatement"
+	MemberExpression                   = "MemberExpression"
+	CallExpression                     = "CallExpression"
+	ClassDeclaration                   = "ClassDeclaration"
+	ClassBody                          = "ClassBody"
+	PropertyDefinition                 = "PropertyDefinition"
+	MethodDefinition                   = "MethodDefinition"
+	FunctionExpression                 = "FunctionExpression"
+	SuperExpression                    = "Super"
+	ThisExpression                     = "ThisExpression"
+	ClassExpression                    = "ClassExpression"
+	AccessorProperty                   = "AccessorProperty"
+	Decorator                          = "Decorator"
+	ErrorNode                          = "ErrorNode"
+	JSXElement                         = "JSXElement"
+	JSXOpeningElement                  = "JSXOpeningElement"
+	JSXClosingElement                  = "JSXClosingElement"
+	JSXFragment                        = "JSXFragment"
+	JSXOpeningFragment                 = "JSXOpeningFragment"
+	JSXClosingFragment                 = "JSXClosingFragment"
+	JSXAttribute                       = "JSXAttribute"
+	JSXSpreadAttribute                 = "JSXSpreadAttribute"
+	JSXExpressionContainer             = "JSXExpressionContainer"
+	JSXEmptyExpression                 = "JSXEmptyExpression"
+	JSXSpreadChild                     = "JSXSpreadChild"
+	JSXText                            = "JSXText"
+	JSXIdentifier                      = "JSXIdentifier"
+	JSXMemberExpression                = "JSXMemberExpression"
+	JSXNamespacedName                  = "JSXNamespacedName"
+	TSTypeAnnotation                   = "TSTypeAnnotation"
+	TSAnyKeyword                       = "TSAnyKeyword"
+	TSUnknownKeyword                   = "TSUnknownKeyword"
+	TSNumberKeyword                    = "TSNumberKeyword"
+	TSObjectKeyword                    = "TSObjectKeyword"
+	TSBooleanKeyword                   = "TSBooleanKeyword"
+	TSBigIntKeyword                    = "TSBigIntKeyword"
+	TSStringKeyword                    = "TSStringKeyword"
+	TSSymbolKeyword                    = "TSSymbolKeyword"
+	TSVoidKeyword                      = "TSVoidKeyword"
+	TSUndefinedKeyword                 = "TSUndefinedKeyword"
+	TSNullKeyword                      = "TSNullKeyword"
+	TSNeverKeyword                     = "TSNeverKeyword"
+	TSThisType                         = "TSThisType"
+	TSTypeReference                    = "TSTypeReference"
+	TSQualifiedName                    = "TSQualifiedName"
+	TSArrayType                        = "TSArrayType"
+	TSIndexedAccessType                = "TSIndexedAccessType"
+	TSUnionType                        = "TSUnionType"
+	TSIntersectionType                 = "TSIntersectionType"
+	TSTypeOperator                     = "TSTypeOperator"
+	TSLiteralType                      = "TSLiteralType"
+	TSTupleType                        = "TSTupleType"
+	TSTypeLiteral                      = "TSTypeLiteral"
+	TSPropertySignature                = "TSPropertySignature"
+	TSMethodSignature                  = "TSMethodSignature"
+	TSIndexSignature                   = "TSIndexSignature"
+	TSFunctionType                     = "TSFunctionType"
+	TSTypeParameterDeclaration         = "TSTypeParameterDeclaration"
+	TSTypeParameter                    = "TSTypeParameter"
+	TSTypeParameterInstantiation       = "TSTypeParameterInstantiation"
+	TSInterfaceDeclaration             = "TSInterfaceDeclaration"
+	TSInterfaceBody                    = "TSInterfaceBody"
+	TSInterfaceHeritage                = "TSInterfaceHeritage"
+	TSTypeAliasDeclaration             = "TSTypeAliasDeclaration"
+	TSEnumDeclaration                  = "TSEnumDeclaration"
+	TSEnumMember                       = "TSEnumMember"
+	TSAsExpression                     = "TSAsExpression"
+	TSSatisfiesExpression              = "TSSatisfiesExpression"
+	TSNonNullExpression                = "TSNonNullExpression"
+	TSParameterProperty                = "TSParameterProperty"
+	TSClassImplements                  = "TSClassImplements"
+	TSAbstractMethodDefinition         = "TSAbstractMethodDefinition"
+	TSAbstractPropertyDefinition       = "TSAbstractPropertyDefinition"
+	TSEmptyBodyFunctionExpression      = "TSEmptyBodyFunctionExpression"
 )
 
+// Types are all the node types the parser produces.
+var Types = []Type{
+	Program,
+	Literal,
+	ExpressionStatement,
+	BlockStatement,
+	EmptyStatement,
+	BinaryExpression,
+	AssignmentExpression,
+	Identifier,
+	VariableDeclaration,
+	VariableDeclarator,
+	IfStatement,
+	LogicalExpression,
+	UnaryExpression,
+	WhileStatement,
+	ForStatement,
+	DoWhileStatement,
+	FunctionDeclaration,
+	ReturnStatement,
+	MemberExpression,
+	CallExpression,
+	ClassDeclaration,
+	ClassBody,
+	PropertyDefinition,
+	MethodDefinition,
+	FunctionExpression,
+	SuperExpression,
+	ThisExpression,
+	ClassExpression,
+	AccessorProperty,
+	Decorator,
+	ErrorNode,
+	JSXElement,
+	JSXOpeningElement,
+	JSXClosingElement,
+	JSXFragment,
+	JSXOpeningFragment,
+	JSXClosingFragment,
+	JSXAttribute,
+	JSXSpreadAttribute,
+	JSXExpressionContainer,
+	JSXEmptyExpression,
+	JSXSpreadChild,
+	JSXText,
+	JSXIdentifier,
+	JSXMemberExpression,
+	JSXNamespacedName,
+	TSTypeAnnotation,
+	TSAnyKeyword,
+	TSUnknownKeyword,
+	TSNumberKeyword,
+	TSObjectKeyword,
+	TSBooleanKeyword,
+	TSBigIntKeyword,
+	TSStringKeyword,
+	TSSymbolKeyword,
+	TSVoidKeyword,
+	TSUndefinedKeyword,
+	TSNullKeyword,
+	TSNeverKeyword,
+	TSThisType,
+	TSTypeReference,
+	TSQualifiedName,
+	TSArrayType,
+	TSIndexedAccessType,
+	TSUnionType,
+	TSIntersectionType,
+	TSTypeOperator,
+	TSLiteralType,
+	TSTupleType,
+	TSTypeLiteral,
+	TSPropertySignature,
+	TSMethodSignature,
+	TSIndexSignature,
+	TSFunctionType,
+	TSTypeParameterDeclaration,
+	TSTypeParameter,
+	TSTypeParameterInstantiation,
+	TSInterfaceDeclaration,
+	TSInterfaceBody,
+	TSInterfaceHeritage,
+	TSTypeAliasDeclaration,
+	TSEnumDeclaration,
+	TSEnumMember,
+	TSAsExpression,
+	TSSatisfiesExpression,
+	TSNonNullExpression,
+	TSParameterProperty,
+	TSClassImplements,
+	TSAbstractMethodDefinition,
+	TSAbstractPropertyDefinition,
+	TSEmptyBodyFunctionExpression,
+}
+
 type Node map[string]interface{}
 
 func (n Node) Type() Type {
@@ -78,6 +237,10 @@ func (n LiteralNode) Value() interface{} {
 	return n["value"]
 }
 
+func (n LiteralNode) Raw() string {
+	return n["raw"].(string)
+}
+
 func NewLiteral(start int, end int, value interface{}, raw string) Node {
 	n := NewNode(Literal, start, end)
 
@@ -103,6 +266,13 @@ func NewProgram(start int, end int, body ...Node) Node {
 
 	return n
 }
+
+// NewErrorNode returns the placeholder for a statement which failed to parse
+// in tolerant mode.
+func NewErrorNode(start int, end int) Node {
+	return NewNode(ErrorNode, start, end)
+}
+
 func NewEmptyStatement(start int, end int) Node {
 	return NewNode(EmptyStatement, start, end)
 }
@@ -288,6 +458,16 @@ func NewClassDeclaration(start int, end int, id Node, superClass Node, body Node
 	return n
 }
 
+func NewClassExpression(start int, end int, id Node, superClass Node, body Node) Node {
+	n := NewNode(ClassExpression, start, end)
+
+	n["id"] = id
+	n["superClass"] = superClass
+	n["body"] = body
+
+	return n
+}
+
 func NewClassBody(start int, end int, body []Node) Node {
 	n := NewNode(ClassBody, start, end)
 
@@ -307,6 +487,14 @@ func NewPropertyDefinition(start int, end int, key Node, value Node) Node {
 	return n
 }
 
+func NewDecorator(start int, end int, expression Node) Node {
+	n := NewNode(Decorator, start, end)
+
+	n["expression"] = expression
+
+	return n
+}
+
 type MethodDefinitionKind string
 
 const (
@@ -334,7 +522,7 @@ func NewFunctionExpression(start int, end int, params []Node, body Node) Node {
 	n["expression"] = false
 	n["generator"] = false
 	n["async"] = false
-	n["id"] = nil
+	n["id"] = Node(nil)
 	n["params"] = params
 	n["body"] = body
 
@@ -348,3 +536,449 @@ func NewSuperExpression(start int, end int) Node {
 func NewThisExpression(start int, end int) Node {
 	return NewNode(ThisExpression, start, end)
 }
+
+func NewJSXElement(start int, end int, openingElement Node, closingElement Node, children []Node) Node {
+	n := NewNode(JSXElement, start, end)
+
+	n["openingElement"] = openingElement
+	n["closingElement"] = closingElement
+	n["children"] = children
+
+	return n
+}
+
+func NewJSXOpeningElement(start int, end int, name Node, attributes []Node, selfClosing bool) Node {
+	n := NewNode(JSXOpeningElement, start, end)
+
+	n["name"] = name
+	n["attributes"] = attributes
+	n["selfClosing"] = selfClosing
+
+	return n
+}
+
+func NewJSXClosingElement(start int, end int, name Node) Node {
+	n := NewNode(JSXClosingElement, start, end)
+
+	n["name"] = name
+
+	return n
+}
+
+func NewJSXFragment(start int, end int, openingFragment Node, closingFragment Node, children []Node) Node {
+	n := NewNode(JSXFragment, start, end)
+
+	n["openingFragment"] = openingFragment
+	n["closingFragment"] = closingFragment
+	n["children"] = children
+
+	return n
+}
+
+func NewJSXOpeningFragment(start int, end int) Node {
+	n := NewNode(JSXOpeningFragment, start, end)
+
+	n["attributes"] = []Node{}
+	n["selfClosing"] = false
+
+	return n
+}
+
+func NewJSXClosingFragment(start int, end int) Node {
+	return NewNode(JSXClosingFragment, start, end)
+}
+
+func NewJSXAttribute(start int, end int, name Node, value Node) Node {
+	n := NewNode(JSXAttribute, start, end)
+
+	n["name"] = name
+	n["value"] = value
+
+	return n
+}
+
+func NewJSXSpreadAttribute(start int, end int, argument Node) Node {
+	n := NewNode(JSXSpreadAttribute, start, end)
+
+	n["argument"] = argument
+
+	return n
+}
+
+func NewJSXExpressionContainer(start int, end int, expression Node) Node {
+	n := NewNode(JSXExpressionContainer, start, end)
+
+	n["expression"] = expression
+
+	return n
+}
+
+func NewJSXEmptyExpression(start int, end int) Node {
+	return NewNode(JSXEmptyExpression, start, end)
+}
+
+func NewJSXSpreadChild(start int, end int, expression Node) Node {
+	n := NewNode(JSXSpreadChild, start, end)
+
+	n["expression"] = expression
+
+	return n
+}
+
+func NewJSXText(start int, end int, value string, raw string) Node {
+	n := NewNode(JSXText, start, end)
+
+	n["value"] = value
+	n["raw"] = raw
+
+	return n
+}
+
+type JSXIdentifierNode Node
+
+func (n JSXIdentifierNode) Name() string {
+	return n["name"].(string)
+}
+
+func NewJSXIdentifier(start int, end int, name string) Node {
+	n := NewNode(JSXIdentifier, start, end)
+
+	n["name"] = name
+
+	return n
+}
+
+func NewJSXMemberExpression(start int, end int, object Node, property Node) Node {
+	n := NewNode(JSXMemberExpression, start, end)
+
+	n["object"] = object
+	n["property"] = property
+
+	return n
+}
+
+func NewJSXNamespacedName(start int, end int, namespace Node, name Node) Node {
+	n := NewNode(JSXNamespacedName, start, end)
+
+	n["namespace"] = namespace
+	n["name"] = name
+
+	return n
+}
+
+// setOptional sets a field only when the child is present, as TypeScript
+// nodes leave out absent optional children instead of setting them to null.
+func setOptional(n Node, key string, child Node) {
+	if child != nil {
+		n[key] = child
+	}
+}
+
+// setOptionalList sets a list field only when the list is not empty.
+func setOptionalList(n Node, key string, children []Node) {
+	if len(children) > 0 {
+		n[key] = children
+	}
+}
+
+func NewTSTypeAnnotation(start int, end int, typeAnnotation Node) Node {
+	n := NewNode(TSTypeAnnotation, start, end)
+
+	n["typeAnnotation"] = typeAnnotation
+
+	return n
+}
+
+// NewTSKeyword creates a node for a keyword type such as TSStringKeyword.
+func NewTSKeyword(t Type, start int, end int) Node {
+	return NewNode(t, start, end)
+}
+
+func NewTSTypeReference(start int, end int, typeName Node, typeArguments Node) Node {
+	n := NewNode(TSTypeReference, start, end)
+
+	n["typeName"] = typeName
+	setOptional(n, "typeArguments", typeArguments)
+
+	return n
+}
+
+func NewTSQualifiedName(start int, end int, left Node, right Node) Node {
+	n := NewNode(TSQualifiedName, start, end)
+
+	n["left"] = left
+	n["right"] = right
+
+	return n
+}
+
+func NewTSArrayType(start int, end int, elementType Node) Node {
+	n := NewNode(TSArrayType, start, end)
+
+	n["elementType"] = elementType
+
+	return n
+}
+
+func NewTSIndexedAccessType(start int, end int, objectType Node, indexType Node) Node {
+	n := NewNode(TSIndexedAccessType, start, end)
+
+	n["objectType"] = objectType
+	n["indexType"] = indexType
+
+	return n
+}
+
+func NewTSUnionType(start int, end int, types []Node) Node {
+	n := NewNode(TSUnionType, start, end)
+
+	n["types"] = types
+
+	return n
+}
+
+func NewTSIntersectionType(start int, end int, types []Node) Node {
+	n := NewNode(TSIntersectionType, start, end)
+
+	n["types"] = types
+
+	return n
+}
+
+func NewTSTypeOperator(start int, end int, operator string, typeAnnotation Node) Node {
+	n := NewNode(TSTypeOperator, start, end)
+
+	n["operator"] = operator
+	n["typeAnnotation"] = typeAnnotation
+
+	return n
+}
+
+func NewTSLiteralType(start int, end int, literal Node) Node {
+	n := NewNode(TSLiteralType, start, end)
+
+	n["literal"] = literal
+
+	return n
+}
+
+func NewTSTupleType(start int, end int, elementTypes []Node) Node {
+	n := NewNode(TSTupleType, start, end)
+
+	n["elementTypes"] = elementTypes
+
+	return n
+}
+
+func NewTSTypeLiteral(start int, end int, members []Node) Node {
+	n := NewNode(TSTypeLiteral, start, end)
+
+	n["members"] = members
+
+	return n
+}
+
+func NewTSPropertySignature(start int, end int, key Node, optional bool, readonly bool, typeAnnotation Node) Node {
+	n := NewNode(TSPropertySignature, start, end)
+
+	n["computed"] = false
+	n["static"] = false
+	n["key"] = key
+	n["optional"] = optional
+	n["readonly"] = readonly
+	setOptional(n, "typeAnnotation", typeAnnotation)
+
+	return n
+}
+
+func NewTSMethodSignature(start int, end int, key Node, optional bool, typeParameters Node, params []Node, returnType Node) Node {
+	n := NewNode(TSMethodSignature, start, end)
+
+	n["computed"] = false
+	n["static"] = false
+	n["readonly"] = false
+	n["kind"] = Method
+	n["key"] = key
+	n["optional"] = optional
+	n["params"] = params
+	setOptional(n, "typeParameters", typeParameters)
+	setOptional(n, "returnType", returnType)
+
+	return n
+}
+
+func NewTSIndexSignature(start int, end int, parameters []Node, typeAnnotation Node, readonly bool) Node {
+	n := NewNode(TSIndexSignature, start, end)
+
+	n["static"] = false
+	n["parameters"] = parameters
+	n["readonly"] = readonly
+	setOptional(n, "typeAnnotation", typeAnnotation)
+
+	return n
+}
+
+func NewTSFunctionType(start int, end int, typeParameters Node, params []Node, returnType Node) Node {
+	n := NewNode(TSFunctionType, start, end)
+
+	n["params"] = params
+	n["returnType"] = returnType
+	setOptional(n, "typeParameters", typeParameters)
+
+	return n
+}
+
+func NewTSTypeParameterDeclaration(start int, end int, params []Node) Node {
+	n := NewNode(TSTypeParameterDeclaration, start, end)
+
+	n["params"] = params
+
+	return n
+}
+
+func NewTSTypeParameter(start int, end int, name Node, constraint Node, defaultType Node) Node {
+	n := NewNode(TSTypeParameter, start, end)
+
+	n["name"] = name
+	n["in"] = false
+	n["out"] = false
+	n["const"] = false
+	setOptional(n, "constraint", constraint)
+	setOptional(n, "default", defaultType)
+
+	return n
+}
+
+func NewTSTypeParameterInstantiation(start int, end int, params []Node) Node {
+	n := NewNode(TSTypeParameterInstantiation, start, end)
+
+	n["params"] = params
+
+	return n
+}
+
+func NewTSInterfaceDeclaration(start int, end int, id Node, typeParameters Node, extends []Node, body Node) Node {
+	n := NewNode(TSInterfaceDeclaration, start, end)
+
+	n["declare"] = false
+	n["id"] = id
+	n["extends"] = extends
+	n["body"] = body
+	setOptional(n, "typeParameters", typeParameters)
+
+	return n
+}
+
+func NewTSInterfaceBody(start int, end int, body []Node) Node {
+	n := NewNode(TSInterfaceBody, start, end)
+
+	n["body"] = body
+
+	return n
+}
+
+func NewTSInterfaceHeritage(start int, end int, expression Node, typeArguments Node) Node {
+	n := NewNode(TSInterfaceHeritage, start, end)
+
+	n["expression"] = expression
+	setOptional(n, "typeArguments", typeArguments)
+
+	return n
+}
+
+func NewTSClassImplements(start int, end int, expression Node, typeArguments Node) Node {
+	n := NewNode(TSClassImplements, start, end)
+
+	n["expression"] = expression
+	setOptional(n, "typeArguments", typeArguments)
+
+	return n
+}
+
+func NewTSTypeAliasDeclaration(start int, end int, id Node, typeParameters Node, typeAnnotation Node) Node {
+	n := NewNode(TSTypeAliasDeclaration, start, end)
+
+	n["declare"] = false
+	n["id"] = id
+	n["typeAnnotation"] = typeAnnotation
+	setOptional(n, "typeParameters", typeParameters)
+
+	return n
+}
+
+func NewTSEnumDeclaration(start int, end int, id Node, members []Node, isConst bool) Node {
+	n := NewNode(TSEnumDeclaration, start, end)
+
+	n["declare"] = false
+	n["const"] = isConst
+	n["id"] = id
+	n["members"] = members
+
+	return n
+}
+
+func NewTSEnumMember(start int, end int, id Node, initializer Node) Node {
+	n := NewNode(TSEnumMember, start, end)
+
+	n["computed"] = false
+	n["id"] = id
+	setOptional(n, "initializer", initializer)
+
+	return n
+}
+
+func NewTSAsExpression(start int, end int, expression Node, typeAnnotation Node) Node {
+	n := NewNode(TSAsExpression, start, end)
+
+	n["expression"] = expression
+	n["typeAnnotation"] = typeAnnotation
+
+	return n
+}
+
+func NewTSSatisfiesExpression(start int, end int, expression Node, typeAnnotation Node) Node {
+	n := NewNode(TSSatisfiesExpression, start, end)
+
+	n["expression"] = expression
+	n["typeAnnotation"] = typeAnnotation
+
+	return n
+}
+
+func NewTSNonNullExpression(start int, end int, expression Node) Node {
+	n := NewNode(TSNonNullExpression, start, end)
+
+	n["expression"] = expression
+
+	return n
+}
+
+func NewTSParameterProperty(start int, end int, accessibility string, readonly bool, override bool, parameter Node) Node {
+	n := NewNode(TSParameterProperty, start, end)
+
+	n["static"] = false
+	n["readonly"] = readonly
+	n["override"] = override
+	n["parameter"] = parameter
+	if accessibility != "" {
+		n["accessibility"] = accessibility
+	}
+
+	return n
+}
+
+func NewTSEmptyBodyFunctionExpression(start int, end int, typeParameters Node, params []Node, returnType Node) Node {
+	n := NewNode(TSEmptyBodyFunctionExpression, start, end)
+
+	n["id"] = Node(nil)
+	n["expression"] = false
+	n["generator"] = false
+	n["async"] = false
+	n["declare"] = false
+	n["params"] = params
+	n["body"] = Node(nil)
+	setOptional(n, "typeParameters", typeParameters)
+	setOptional(n, "returnType", returnType)
+
+	return n
+}
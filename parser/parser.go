@@ -1,91 +1,328 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"runtime/debug"
-	"strings"
+	"sort"
 
 	"github.com/0xvesion/go-js-parser/tokenizer"
 )
 
+// Parser parses a program. Like JavaScript engines, it inserts the ';' a
+// statement ends with when it is missing before a '}', at the end of the
+// source, at the end of a line the next one can't continue or after the ')'
+// of a do-while statement. A 'return' at the end of a line returns nothing.
 type Parser interface {
 	Parse() (Node, error)
 }
 
+// ContextParser is a Parser which can stop once a context is done, like the
+// parsers of New and NewWithOptions.
+type ContextParser interface {
+	Parser
+	// ParseContext parses like Parse, but stops with a *CanceledError once
+	// ctx is done. A nil ctx is never done.
+	ParseContext(ctx context.Context) (Node, error)
+}
+
+// ParseContext parses with p, stopping once ctx is done if p is a
+// ContextParser. Other parsers only check ctx before parsing.
+func ParseContext(ctx context.Context, p Parser) (Node, error) {
+	if cp, ok := p.(ContextParser); ok {
+		return cp.ParseContext(ctx)
+	}
+
+	if ctx != nil && ctx.Err() != nil {
+		return nil, &CanceledError{Err: ctx.Err()}
+	}
+
+	return p.Parse()
+}
+
+// Options enables syntax extensions and changes what the parser produces.
+// The zero value parses plain JavaScript.
+type Options struct {
+	// JSX enables JSX elements and fragments in expressions.
+	JSX bool
+	// TypeScript enables type annotations and declarations, producing
+	// typescript-estree compatible TS* nodes. Optional TypeScript fields are
+	// only set when the syntax is used.
+	TypeScript bool
+	// EcmaVersion is the language edition to accept, given either as an
+	// edition number (3, 5, 6, ...) or a year (2015, 2016, ...). Syntax from
+	// later editions is rejected, and before ES2015 'let' is an identifier.
+	// Zero accepts LatestEcmaVersion, and Parse fails on editions the parser
+	// doesn't know.
+	EcmaVersion int
+	// Decorators enables '@decorator' on classes and class members and
+	// 'accessor' fields.
+	Decorators bool
+	// Locations adds a 'loc' with the line and column of the start and end
+	// to every node.
+	Locations bool
+	// SourceFile is the 'source' of every 'loc'.
+	SourceFile string
+	// Tolerant recovers from syntax errors. Parse then returns the partial
+	// program, with an ErrorNode for every statement it skipped and empty
+	// Identifiers for missing expressions, along with every error found.
+	Tolerant bool
+	// MaxDepth bounds the nesting of statements and expressions. Zero means
+	// DefaultMaxDepth.
+	MaxDepth int
+	// MaxTokens bounds the number of tokens read, including the ones read
+	// again after backtracking. Zero means no limit.
+	MaxTokens int
+	// MaxSourceBytes bounds the size of the source. Zero means no limit.
+	MaxSourceBytes int
+}
+
+// LatestEcmaVersion is the newest language edition the parser supports.
+const LatestEcmaVersion = 2022
+
+// tokenEcmaVersions are the editions which introduced token kinds.
+var tokenEcmaVersions = map[tokenizer.Type]int{
+	tokenizer.VariableDeclarationKeyword: 2015,
+	tokenizer.ClassKeyword:               2015,
+	tokenizer.ExtendsKeyword:             2015,
+	tokenizer.SuperKeyword:               2015,
+}
+
 type parser struct {
 	t          tokenizer.Tokenizer
+	options    Options
+	ctx        context.Context
 	lookAhead  tokenizer.Token
 	lookBehind tokenizer.Token
+	lineIndex  *tokenizer.LineIndex
+	depth      int
+	tokens     int
+	// errors are the syntax errors recovered from in tolerant mode.
+	errors ErrorList
 }
 
 func New(t tokenizer.Tokenizer) Parser {
-	lookAhead, err := t.Next()
-	if err != nil {
-		panic(err)
+	return NewWithOptions(t, Options{})
+}
+
+// NewWithOptions returns a parser with the given options. Reading the source
+// is deferred to Parse, which reports every error.
+func NewWithOptions(t tokenizer.Tokenizer, options Options) Parser {
+	if options.MaxDepth == 0 {
+		options.MaxDepth = DefaultMaxDepth
+	}
+
+	switch {
+	case options.EcmaVersion == 0:
+		options.EcmaVersion = LatestEcmaVersion
+	case options.EcmaVersion >= 6 && options.EcmaVersion <= LatestEcmaVersion-2009:
+		options.EcmaVersion += 2009
 	}
 
 	return &parser{
-		t:         t,
-		lookAhead: lookAhead,
+		t:       t,
+		options: options,
 	}
 }
 
-func (p *parser) Parse() (n Node, err error) {
+func (p *parser) Parse() (Node, error) {
+	return p.ParseContext(context.Background())
+}
+
+func (p *parser) ParseContext(ctx context.Context) (n Node, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p.ctx = ctx
+
 	defer func() {
 		if r := recover(); r != nil {
-			err = p.formatError(r)
+			if isRuntimeError(r) {
+				n, err = nil, &InternalError{Offset: p.offset(p.lookAhead), Err: r.(error), Stack: debug.Stack()}
+			} else if isAbort(r) {
+				n, err = nil, r.(error)
+			} else {
+				n, err = nil, p.syntaxError(r)
+			}
 		}
 	}()
 
-	n = p.program()
+	if v := p.options.EcmaVersion; v != 3 && v != 5 && (v < 2015 || v > LatestEcmaVersion) {
+		return nil, fmt.Errorf("unsupported ecmaVersion %d: want 3, 5, 6 to %d or 2015 to %d", v, LatestEcmaVersion-2009, LatestEcmaVersion)
+	}
 
-	return
-}
+	if p.options.MaxSourceBytes > 0 && len(p.t.Src()) > p.options.MaxSourceBytes {
+		return nil, fmt.Errorf("%w: source of %d bytes exceeds %d bytes", ErrLimitExceeded, len(p.t.Src()), p.options.MaxSourceBytes)
+	}
 
-func (p *parser) formatError(err any) error {
-	res := ""
-	cursor := p.t.Cursor()
-	resLine := 0
-	resCol := 0
+	p.lookAhead = p.next()
+	n = p.program()
 
-	for i, line := range strings.Split(p.t.Src(), "\n") {
-		res += fmt.Sprintf("%02d  %s\n", i, line)
-		if cursor-len(line) <= 0 {
-			res += "  "
-			for ii := 0; ii < cursor; ii++ {
-				res += " "
-			}
-			res += "  ^"
+	errs := earlyErrors(n)
+	for _, err := range errs {
+		p.locate(err)
+	}
 
-			resLine = i
-			resCol = cursor
-			break
-		}
-		cursor -= len(line)
+	if len(errs) > 0 && !p.options.Tolerant {
+		return nil, errs
 	}
 
-	res = fmt.Sprintf("Ln %02d, Col %02d\n%s", resLine, resCol, res)
+	if p.options.Locations {
+		p.addLocations(n)
+	}
+
+	if errs = append(p.errors, errs...); len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].Offset < errs[j].Offset
+		})
+
+		return n, errs
+	}
 
-	return fmt.Errorf("%v\n%s\n%s", err, res, debug.Stack())
+	return
 }
 
 func (p *parser) consume(t tokenizer.Type) tokenizer.Token {
 	token := p.lookAhead
 
 	if token.Type != t {
-		panic(fmt.Errorf("unexpected token type. want: %s got: %s", t, token.Type))
+		panic(&SyntaxError{
+			Offset:   p.offset(token),
+			Expected: []tokenizer.Type{t},
+			Found:    token,
+			Message:  fmt.Sprintf("unexpected token type. want: %s got: %s", t, token.Type),
+		})
 	}
 
-	lookAhead, err := p.t.Next()
-	if err != nil {
-		panic(err)
+	if version, ok := tokenEcmaVersions[t]; ok {
+		p.requireEcmaVersion(version, fmt.Sprintf("'%s'", token.Value))
 	}
-	p.lookAhead = lookAhead
+
+	p.lookAhead = p.next()
 	p.lookBehind = token
 
 	return token
 }
 
+// next reads the following token from the tokenizer.
+func (p *parser) next() tokenizer.Token {
+	p.countToken()
+	token, err := p.t.Next()
+
+	var lexErr *tokenizer.LexError
+	for p.options.Tolerant && errors.As(err, &lexErr) {
+		p.recordError(&SyntaxError{Offset: lexErr.Offset, Message: lexErr.Message, Err: err})
+		p.countToken()
+		token, err = p.t.Next()
+	}
+
+	if errors.As(err, &lexErr) {
+		panic(&SyntaxError{Offset: lexErr.Offset, Message: lexErr.Message, Err: err})
+	} else if err != nil {
+		panic(err)
+	}
+
+	if token.Is(tokenizer.VariableDeclarationKeyword) && token.Value == "let" && p.options.EcmaVersion < 2015 {
+		token.Type = tokenizer.Identifier
+	}
+
+	return token
+}
+
+// semicolon consumes the ';' ending a statement and returns the end of the
+// statement. Like JavaScript's automatic semicolon insertion, it accepts a
+// missing ';' where canInsertSemicolon allows it.
+func (p *parser) semicolon() int {
+	if p.lookAhead.Not(tokenizer.Semicolon) && p.canInsertSemicolon() {
+		return p.lookBehind.End
+	}
+
+	return p.consume(tokenizer.Semicolon).End
+}
+
+// canInsertSemicolon reports whether a statement may end without a ';'
+// before the lookahead: before a '}', at the end of the source or at the end
+// of a line.
+func (p *parser) canInsertSemicolon() bool {
+	if p.lookAhead.Is(tokenizer.ClosingCurlyBrace, tokenizer.None) {
+		return true
+	}
+
+	return p.lines().Position(p.lookBehind.End).Line < p.lines().Position(p.lookAhead.Start).Line
+}
+
+func (p *parser) consumeValue(t tokenizer.Type, value string) tokenizer.Token {
+	if p.lookAhead.Value != value {
+		panic(&SyntaxError{
+			Offset:   p.offset(p.lookAhead),
+			Expected: []tokenizer.Type{t},
+			Found:    p.lookAhead,
+			Message:  fmt.Sprintf("unexpected token. want: %s got: %s", value, p.lookAhead.Value),
+		})
+	}
+
+	return p.consume(t)
+}
+
+// requireEcmaVersion rejects a feature introduced after the targeted edition.
+func (p *parser) requireEcmaVersion(version int, feature string) {
+	if p.options.EcmaVersion < version {
+		panic(fmt.Errorf("%s requires ecmaVersion >= %d", feature, version))
+	}
+}
+
+// state is a snapshot of the parser position.
+type state struct {
+	cursor     int
+	lookAhead  tokenizer.Token
+	lookBehind tokenizer.Token
+	errors     int
+}
+
+func (p *parser) save() state {
+	return state{p.t.Cursor(), p.lookAhead, p.lookBehind, len(p.errors)}
+}
+
+func (p *parser) restore(s state) {
+	p.t.Seek(s.cursor)
+	p.lookAhead = s.lookAhead
+	p.lookBehind = s.lookBehind
+	p.errors = p.errors[:s.errors]
+}
+
+// try parses a production speculatively and backtracks if it fails.
+func (p *parser) try(production func() Node) (n Node, ok bool) {
+	s := p.save()
+
+	defer func() {
+		if r := recover(); r != nil {
+			if isAbort(r) {
+				panic(r)
+			}
+
+			p.restore(s)
+			n, ok = nil, false
+		}
+	}()
+
+	return production(), true
+}
+
+// peek returns the token following the lookahead.
+func (p *parser) peek() tokenizer.Token {
+	s := p.save()
+	defer p.restore(s)
+
+	p.consumeAny()
+
+	return p.lookAhead
+}
+
+func (p *parser) isLookaheadValue(t tokenizer.Type, value string) bool {
+	return p.lookAhead.Type == t && p.lookAhead.Value == value
+}
+
 func (p *parser) binaryExpression(
 	builder func() Node,
 	operator tokenizer.Type,
@@ -132,20 +369,36 @@ func (p *parser) consumeAny() tokenizer.Token {
 	return p.consume(p.lookAhead.Type)
 }
 
+// addDirectives marks the directive prologue of a program or function body,
+// i.e. the leading expression statements consisting of a single unparenthesized
+// string literal.
 func (p *parser) addDirectives(sl []Node) []Node {
-	for k, v := range sl {
-		if v.Is(ExpressionStatement) {
-			statement := ExpressionStatementNode(v)
-			exp := statement.Expression()
-			if exp.Is(Literal) {
-				value := LiteralNode(exp).Value()
-				if str, ok := value.(string); ok {
-					statement.SetDirective(str)
-					sl[k] = v
-				}
-			}
+	for _, v := range sl {
+		if !p.isDirectiveCandidate(v) {
+			break
 		}
+
+		raw := LiteralNode(ExpressionStatementNode(v).Expression()).Raw()
+		ExpressionStatementNode(v).SetDirective(raw[1 : len(raw)-1])
 	}
 
 	return sl
 }
+
+func (p *parser) isDirectiveCandidate(n Node) bool {
+	if n.Not(ExpressionStatement) {
+		return false
+	}
+
+	exp := ExpressionStatementNode(n).Expression()
+	if exp.Not(Literal) {
+		return false
+	}
+
+	if _, ok := LiteralNode(exp).Value().(string); !ok {
+		return false
+	}
+
+	// A parenthesized string starts after the statement does.
+	return exp.Start() == n.Start()
+}
@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
@@ -10,9 +11,14 @@ import (
 	"os"
 	"os/exec"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/schema"
 	"github.com/0xvesion/go-js-parser/tokenizer"
 )
 
@@ -91,6 +97,9 @@ func goParser(src string) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if errs := schema.Validate(actualAst); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid tree: %v", errs)
+	}
 	actualAstJson, _ := json.MarshalIndent(actualAst, "", "  ")
 	x := &map[string]interface{}{}
 	err = json.Unmarshal(actualAstJson, x)
@@ -321,3 +330,874 @@ func TestClassDeclaration(t *testing.T) {
 	// TOOD: Add support for getters/setters
 	// TODO: Add support for static/async modifiers
 }
+
+func earlyErrorTest(t *testing.T, src string, message string) {
+	_, err := parser.New(tokenizer.New(src)).Parse()
+
+	var errs parser.ErrorList
+	if !errors.As(err, &errs) {
+		t.Errorf("Expected early error %q for %q, got: %v", message, src, err)
+		return
+	}
+
+	if errs[0].Message != message {
+		t.Errorf("Unexpected early error for %q. want: %q got: %q", src, message, errs[0].Message)
+	}
+}
+
+func TestEarlyErrors(t *testing.T) {
+	earlyErrorTest(t, `let let = 1;`, "let is disallowed as a lexically bound name")
+	earlyErrorTest(t, `"use strict"; arguments = 1;`, "Assigning to arguments in strict mode")
+	earlyErrorTest(t, `function f() { "use strict"; eval = 1; }`, "Assigning to eval in strict mode")
+	earlyErrorTest(t, `function eval() { "use strict"; }`, "Binding eval in strict mode")
+	earlyErrorTest(t, `class A { constructor() { super(); } }`, "super() call outside constructor of a subclass")
+	earlyErrorTest(t, `class A extends B { test() { super(); } }`, "super() call outside constructor of a subclass")
+	earlyErrorTest(t, `function f() { super.x; }`, "'super' keyword outside a method")
+	earlyErrorTest(t, `return;`, "'return' outside of function")
+	earlyErrorTest(t, `let a; let a;`, "Identifier 'a' has already been declared")
+	earlyErrorTest(t, `let a; function a() {}`, "Identifier 'a' has already been declared")
+	earlyErrorTest(t, `function f(a) { const a = 1; }`, "Identifier 'a' has already been declared")
+	earlyErrorTest(t, `{ class A {} let A; }`, "Identifier 'A' has already been declared")
+	earlyErrorTest(t, `"use strict"; 010;`, "Octal literal in strict mode")
+	earlyErrorTest(t, `"use strict"; "\01";`, "Octal literal in strict mode")
+	earlyErrorTest(t, `"use strict"; 08;`, "Octal literal in strict mode")
+	earlyErrorTest(t, `"use strict"; function f(a, a) {}`, "Argument name clash")
+	earlyErrorTest(t, `class A { test(a, a) {} }`, "Argument name clash")
+	earlyErrorTest(t, `class A { constructor() {} constructor() {} }`, "Duplicate constructor in the same class")
+	earlyErrorTest(t, `class A { constructor = 1; }`, "Classes can't have a field named 'constructor'")
+	earlyErrorTest(t, `function f(static) { "use strict"; }`, "The keyword 'static' is reserved")
+}
+
+func TestStrictModeValidPrograms(t *testing.T) {
+	for _, src := range []string{
+		`010;`,
+		`arguments = 1;`,
+		`function f(a, a) {}`,
+		`let a; { let a; }`,
+		`for (let i = 0; i < 1; i+=1) { let i; }`,
+		`function f() { return; }`,
+		`{ function a() {} function a() {} }`,
+		`"foo"; "use strict"; function f(x) { return x; }`,
+		`1; "use strict"; arguments = 1;`,
+		`("use strict"); arguments = 1;`,
+		`class A extends B { constructor() { super(); super.x; } test() { super.test(); } }`,
+	} {
+		if _, err := parser.New(tokenizer.New(src)).Parse(); err != nil {
+			t.Errorf("Unexpected error for %q: %v", src, err)
+		}
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	ast, err := parser.New(tokenizer.New(`"use strict"; 'b'; ("c"); "d";`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := ast["body"].([]parser.Node)
+	expected := []interface{}{"use strict", "b", nil, nil}
+	for i, directive := range expected {
+		if body[i]["directive"] != directive {
+			t.Errorf("Unexpected directive for statement %d. want: %v got: %v", i, directive, body[i]["directive"])
+		}
+	}
+}
+
+func optionsTest(t *testing.T, src string, options parser.Options, expected string) {
+	actualAst, err := parser.NewWithOptions(tokenizer.New(src), options).Parse()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, err := range schema.Validate(actualAst) {
+		t.Errorf("%s: %v", src, err)
+	}
+
+	actualJson, _ := json.Marshal(actualAst)
+	actual := map[string]interface{}{}
+	json.Unmarshal(actualJson, &actual)
+
+	reference := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(expected), &reference); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(reference, actual) {
+		t.Errorf("Invalid ast.\nwant: %s\ngot: %s\n", expected, actualJson)
+	}
+}
+
+func TestJSX(t *testing.T) {
+	optionsTest(t, `<a b="&lt;" {...c}>x{y}<></></a>;`, parser.Options{JSX: true}, `{"type":"Program","start":0,"end":33,"sourceType":"script","body":[
+		{"type":"ExpressionStatement","start":0,"end":33,"expression":{"type":"JSXElement","start":0,"end":32,
+			"openingElement":{"type":"JSXOpeningElement","start":0,"end":19,"selfClosing":false,
+				"name":{"type":"JSXIdentifier","start":1,"end":2,"name":"a"},
+				"attributes":[
+					{"type":"JSXAttribute","start":3,"end":11,
+						"name":{"type":"JSXIdentifier","start":3,"end":4,"name":"b"},
+						"value":{"type":"Literal","start":5,"end":11,"value":"<","raw":"\"&lt;\""}},
+					{"type":"JSXSpreadAttribute","start":12,"end":18,"argument":{"type":"Identifier","start":16,"end":17,"name":"c"}}]},
+			"closingElement":{"type":"JSXClosingElement","start":28,"end":32,"name":{"type":"JSXIdentifier","start":30,"end":31,"name":"a"}},
+			"children":[
+				{"type":"JSXText","start":19,"end":20,"value":"x","raw":"x"},
+				{"type":"JSXExpressionContainer","start":20,"end":23,"expression":{"type":"Identifier","start":21,"end":22,"name":"y"}},
+				{"type":"JSXFragment","start":23,"end":28,"children":[],
+					"openingFragment":{"type":"JSXOpeningFragment","start":23,"end":25,"attributes":[],"selfClosing":false},
+					"closingFragment":{"type":"JSXClosingFragment","start":25,"end":28}}]}}]}`)
+
+	optionsTest(t, `<a:b c:d/>;`, parser.Options{JSX: true}, `{"type":"Program","start":0,"end":11,"sourceType":"script","body":[
+		{"type":"ExpressionStatement","start":0,"end":11,"expression":{"type":"JSXElement","start":0,"end":10,"children":[],"closingElement":null,
+			"openingElement":{"type":"JSXOpeningElement","start":0,"end":10,"selfClosing":true,
+				"name":{"type":"JSXNamespacedName","start":1,"end":4,
+					"namespace":{"type":"JSXIdentifier","start":1,"end":2,"name":"a"},
+					"name":{"type":"JSXIdentifier","start":3,"end":4,"name":"b"}},
+				"attributes":[{"type":"JSXAttribute","start":5,"end":8,"value":null,
+					"name":{"type":"JSXNamespacedName","start":5,"end":8,
+						"namespace":{"type":"JSXIdentifier","start":5,"end":6,"name":"c"},
+						"name":{"type":"JSXIdentifier","start":7,"end":8,"name":"d"}}}]}}}]}`)
+
+	optionsTest(t, `x = <A.B.C>{}</A.B.C>;`, parser.Options{JSX: true}, `{"type":"Program","start":0,"end":22,"sourceType":"script","body":[
+		{"type":"ExpressionStatement","start":0,"end":22,"expression":{"type":"AssignmentExpression","start":0,"end":21,"operator":"=",
+			"left":{"type":"Identifier","start":0,"end":1,"name":"x"},
+			"right":{"type":"JSXElement","start":4,"end":21,
+				"openingElement":{"type":"JSXOpeningElement","start":4,"end":11,"selfClosing":false,"attributes":[],
+					"name":{"type":"JSXMemberExpression","start":5,"end":10,
+						"object":{"type":"JSXMemberExpression","start":5,"end":8,
+							"object":{"type":"JSXIdentifier","start":5,"end":6,"name":"A"},
+							"property":{"type":"JSXIdentifier","start":7,"end":8,"name":"B"}},
+						"property":{"type":"JSXIdentifier","start":9,"end":10,"name":"C"}}},
+				"closingElement":{"type":"JSXClosingElement","start":13,"end":21,
+					"name":{"type":"JSXMemberExpression","start":15,"end":20,
+						"object":{"type":"JSXMemberExpression","start":15,"end":18,
+							"object":{"type":"JSXIdentifier","start":15,"end":16,"name":"A"},
+							"property":{"type":"JSXIdentifier","start":17,"end":18,"name":"B"}},
+						"property":{"type":"JSXIdentifier","start":19,"end":20,"name":"C"}}},
+				"children":[{"type":"JSXExpressionContainer","start":11,"end":13,
+					"expression":{"type":"JSXEmptyExpression","start":12,"end":12}}]}}}]}`)
+}
+
+func TestJSXErrors(t *testing.T) {
+	for _, src := range []string{
+		`<a></b>;`,
+		`<a>}</a>;`,
+		`<a b={}/>;`,
+		`<a>`,
+	} {
+		if _, err := parser.NewWithOptions(tokenizer.New(src), parser.Options{JSX: true}).Parse(); err == nil {
+			t.Errorf("Expected an error for %q", src)
+		}
+	}
+
+	if _, err := parser.New(tokenizer.New(`<a/>;`)).Parse(); err == nil {
+		t.Error("Expected JSX to be rejected without the JSX option")
+	}
+}
+
+func TestTypeScript(t *testing.T) {
+	optionsTest(t, `let x: number[] = y as any;`, parser.Options{TypeScript: true}, `{"type":"Program","start":0,"end":27,"sourceType":"script","body":[
+		{"type":"VariableDeclaration","start":0,"end":27,"kind":"let","declarations":[
+			{"type":"VariableDeclarator","start":4,"end":26,
+				"id":{"type":"Identifier","start":4,"end":15,"name":"x",
+					"typeAnnotation":{"type":"TSTypeAnnotation","start":5,"end":15,
+						"typeAnnotation":{"type":"TSArrayType","start":7,"end":15,"elementType":{"type":"TSNumberKeyword","start":7,"end":13}}}},
+				"init":{"type":"TSAsExpression","start":18,"end":26,
+					"expression":{"type":"Identifier","start":18,"end":19,"name":"y"},
+					"typeAnnotation":{"type":"TSAnyKeyword","start":23,"end":26}}}]}]}`)
+
+	optionsTest(t, `interface A<T> { b?: T; }`, parser.Options{TypeScript: true}, `{"type":"Program","start":0,"end":25,"sourceType":"script","body":[
+		{"type":"TSInterfaceDeclaration","start":0,"end":25,"declare":false,"extends":[],
+			"id":{"type":"Identifier","start":10,"end":11,"name":"A"},
+			"typeParameters":{"type":"TSTypeParameterDeclaration","start":11,"end":14,"params":[
+				{"type":"TSTypeParameter","start":12,"end":13,"const":false,"in":false,"out":false,"name":{"type":"Identifier","start":12,"end":13,"name":"T"}}]},
+			"body":{"type":"TSInterfaceBody","start":15,"end":25,"body":[
+				{"type":"TSPropertySignature","start":17,"end":23,"computed":false,"optional":true,"readonly":false,"static":false,
+					"key":{"type":"Identifier","start":17,"end":18,"name":"b"},
+					"typeAnnotation":{"type":"TSTypeAnnotation","start":19,"end":22,
+						"typeAnnotation":{"type":"TSTypeReference","start":21,"end":22,"typeName":{"type":"Identifier","start":21,"end":22,"name":"T"}}}}]}}]}`)
+
+	optionsTest(t, `f<T>(a!);`, parser.Options{TypeScript: true}, `{"type":"Program","start":0,"end":9,"sourceType":"script","body":[
+		{"type":"ExpressionStatement","start":0,"end":9,"expression":{"type":"CallExpression","start":0,"end":8,"optional":false,
+			"callee":{"type":"Identifier","start":0,"end":1,"name":"f"},
+			"typeArguments":{"type":"TSTypeParameterInstantiation","start":1,"end":4,"params":[
+				{"type":"TSTypeReference","start":2,"end":3,"typeName":{"type":"Identifier","start":2,"end":3,"name":"T"}}]},
+			"arguments":[{"type":"TSNonNullExpression","start":5,"end":7,"expression":{"type":"Identifier","start":5,"end":6,"name":"a"}}]}}]}`)
+
+	optionsTest(t, `type U = "a" | keyof B;`, parser.Options{TypeScript: true}, `{"type":"Program","start":0,"end":23,"sourceType":"script","body":[
+		{"type":"TSTypeAliasDeclaration","start":0,"end":23,"declare":false,
+			"id":{"type":"Identifier","start":5,"end":6,"name":"U"},
+			"typeAnnotation":{"type":"TSUnionType","start":9,"end":22,"types":[
+				{"type":"TSLiteralType","start":9,"end":12,"literal":{"type":"Literal","start":9,"end":12,"value":"a","raw":"\"a\""}},
+				{"type":"TSTypeOperator","start":15,"end":22,"operator":"keyof",
+					"typeAnnotation":{"type":"TSTypeReference","start":21,"end":22,"typeName":{"type":"Identifier","start":21,"end":22,"name":"B"}}}]}}]}`)
+}
+
+func TestTypeScriptErrors(t *testing.T) {
+	for _, src := range []string{
+		`let x: = 1;`,
+		`interface A {`,
+		`enum E { A B }`,
+		`function f(a: number { }`,
+	} {
+		if _, err := parser.NewWithOptions(tokenizer.New(src), parser.Options{TypeScript: true}).Parse(); err == nil {
+			t.Errorf("Expected an error for %q", src)
+		}
+	}
+
+	if _, err := parser.New(tokenizer.New(`let x: number = 1;`)).Parse(); err == nil {
+		t.Error("Expected type annotations to be rejected without the TypeScript option")
+	}
+}
+
+func TestEcmaVersion(t *testing.T) {
+	tests := []struct {
+		src         string
+		ecmaVersion int
+		err         string
+	}{
+		{`x = 1;`, 5, ""},
+		{`function f() { return this; }`, 3, ""},
+		{`let x = 1;`, 5, "'let' requires ecmaVersion >= 2015"},
+		{`let x = 1;`, 6, ""},
+		{`const x = 1;`, 2015, ""},
+		{`class A {}`, 5, "'class' requires ecmaVersion >= 2015"},
+		{`class A { m() {} }`, 2019, ""},
+		{`class A { x = 1; }`, 2019, "class field requires ecmaVersion >= 2022"},
+		{`class A { static x; }`, 13, ""},
+		{`class A { x = 1; }`, 0, ""},
+		{`let = 1; x = let;`, 5, ""},
+		{`let = 1;`, 3, ""},
+		{`let[0] = 1;`, 5, ""},
+		{"let\nx = 1;", 5, ""},
+		{`let = 1;`, 2015, "want: Identifier"},
+		{`let x;`, 2015, ""},
+		{`for (let i = 0; i < 1;) {}`, 5, "'let' requires ecmaVersion >= 2015"},
+		{`for (let i = 0; i < 1;) {}`, 6, ""},
+		{`const x = 1;`, 5, "'const' requires ecmaVersion >= 2015"},
+		{`const x = 1;`, 6, ""},
+		{`class A extends B { constructor() { super(); } }`, 5, "'class' requires ecmaVersion >= 2015"},
+		{`class A extends B { constructor() { super(); } }`, 2015, ""},
+		{`class A { x = 1; }`, 2021, "class field requires ecmaVersion >= 2022"},
+		{`class A { x = 1; }`, 12, "class field requires ecmaVersion >= 2022"},
+		{`class A { x = 1; }`, 2022, ""},
+		{`do x; while (y) z;`, 5, "want: Semicolon"},
+		{`do x; while (y) z;`, 2015, ""},
+		{`x;`, 4, "unsupported ecmaVersion 4"},
+		{`x;`, 14, "unsupported ecmaVersion 14"},
+		{`x;`, 15, "unsupported ecmaVersion 15"},
+		{`x;`, 2014, "unsupported ecmaVersion 2014"},
+		{`x;`, 2023, "unsupported ecmaVersion 2023"},
+		{`x;`, 2030, "unsupported ecmaVersion 2030"},
+		{`x;`, -1, "unsupported ecmaVersion -1"},
+	}
+
+	for _, test := range tests {
+		options := parser.Options{EcmaVersion: test.ecmaVersion}
+		_, err := parser.NewWithOptions(tokenizer.New(test.src), options).Parse()
+
+		if test.err == "" && err != nil {
+			t.Errorf("Unexpected error for %q with ecmaVersion %d: %v", test.src, test.ecmaVersion, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("Expected %q for %q with ecmaVersion %d, got: %v", test.err, test.src, test.ecmaVersion, err)
+		}
+	}
+}
+
+func TestClassExpression(t *testing.T) {
+	optionsTest(t, `x = class {};`, parser.Options{}, `{"type":"Program","start":0,"end":13,"sourceType":"script","body":[
+		{"type":"ExpressionStatement","start":0,"end":13,"expression":{"type":"AssignmentExpression","start":0,"end":12,"operator":"=",
+			"left":{"type":"Identifier","start":0,"end":1,"name":"x"},
+			"right":{"type":"ClassExpression","start":4,"end":12,"id":null,"superClass":null,
+				"body":{"type":"ClassBody","start":10,"end":12,"body":[]}}}}]}`)
+}
+
+func TestDecorators(t *testing.T) {
+	optionsTest(t, `@a.b(1) class A { @c accessor x; }`, parser.Options{Decorators: true}, `{"type":"Program","start":0,"end":34,"sourceType":"script","body":[
+		{"type":"ClassDeclaration","start":0,"end":34,"superClass":null,
+			"decorators":[{"type":"Decorator","start":0,"end":7,
+				"expression":{"type":"CallExpression","start":1,"end":7,"optional":false,
+					"callee":{"type":"MemberExpression","start":1,"end":4,"computed":false,"optional":false,
+						"object":{"type":"Identifier","start":1,"end":2,"name":"a"},
+						"property":{"type":"Identifier","start":3,"end":4,"name":"b"}},
+					"arguments":[{"type":"Literal","start":5,"end":6,"value":1,"raw":"1"}]}}],
+			"id":{"type":"Identifier","start":14,"end":15,"name":"A"},
+			"body":{"type":"ClassBody","start":16,"end":34,"body":[
+				{"type":"AccessorProperty","start":18,"end":32,"computed":false,"static":false,"value":null,
+					"decorators":[{"type":"Decorator","start":18,"end":20,"expression":{"type":"Identifier","start":19,"end":20,"name":"c"}}],
+					"key":{"type":"Identifier","start":30,"end":31,"name":"x"}}]}}]}`)
+
+	for _, src := range []string{
+		`@a;`,
+		`class A { @a }`,
+		`class A { accessor m() {} }`,
+	} {
+		if _, err := parser.NewWithOptions(tokenizer.New(src), parser.Options{Decorators: true}).Parse(); err == nil {
+			t.Errorf("Expected an error for %q", src)
+		}
+	}
+
+	if _, err := parser.New(tokenizer.New(`@a class A {}`)).Parse(); err == nil {
+		t.Error("Expected decorators to be rejected without the Decorators option")
+	}
+}
+
+func TestLocations(t *testing.T) {
+	options := parser.Options{Locations: true, SourceFile: "a.js"}
+	optionsTest(t, "x = 1;\r\n  y;", options, `{"type":"Program","start":0,"end":12,"sourceType":"script",
+		"loc":{"start":{"line":1,"column":0},"end":{"line":2,"column":4},"source":"a.js"},
+		"body":[
+			{"type":"ExpressionStatement","start":0,"end":6,
+				"loc":{"start":{"line":1,"column":0},"end":{"line":1,"column":6},"source":"a.js"},
+				"expression":{"type":"AssignmentExpression","start":0,"end":5,"operator":"=",
+					"loc":{"start":{"line":1,"column":0},"end":{"line":1,"column":5},"source":"a.js"},
+					"left":{"type":"Identifier","start":0,"end":1,"name":"x",
+						"loc":{"start":{"line":1,"column":0},"end":{"line":1,"column":1},"source":"a.js"}},
+					"right":{"type":"Literal","start":4,"end":5,"value":1,"raw":"1",
+						"loc":{"start":{"line":1,"column":4},"end":{"line":1,"column":5},"source":"a.js"}}}},
+			{"type":"ExpressionStatement","start":10,"end":12,
+				"loc":{"start":{"line":2,"column":2},"end":{"line":2,"column":4},"source":"a.js"},
+				"expression":{"type":"Identifier","start":10,"end":11,"name":"y",
+					"loc":{"start":{"line":2,"column":2},"end":{"line":2,"column":3},"source":"a.js"}}}]}`)
+}
+
+func TestAutomaticSemicolonInsertion(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+		options  parser.Options
+	}{
+		{"a\nb", "a; b;", parser.Options{}},
+		{"let a = 1\nlet b = a\n+ 1", "let a = 1; let b = a + 1;", parser.Options{}},
+		{"let a, b\nlet c\n= 1", "let a, b; let c = 1;", parser.Options{}},
+		{"function f() { return\na }", "function f() { return; a; }", parser.Options{}},
+		{"{ a } if (a) b\nelse c", "{ a; } if (a) b; else c;", parser.Options{}},
+		{"do a; while (b)\nc", "do a; while (b); c;", parser.Options{}},
+		{"do a; while (b) c", "do a; while (b); c;", parser.Options{}},
+		{"x\n(y)", "x(y);", parser.Options{}},
+		{"x\n[0]", "x[0];", parser.Options{}},
+		{"class A { a = 1\nb\nm() {} }", "class A { a = 1; b; m() {} }", parser.Options{}},
+		{"type T = A\ninterface I { a: T\nb(): void }", "type T = A; interface I { a: T; b(): void; }", parser.Options{TypeScript: true}},
+	}
+
+	for _, test := range tests {
+		actual, err := parser.NewWithOptions(tokenizer.New(test.src), test.options).Parse()
+		if err != nil {
+			t.Errorf("%q: %v", test.src, err)
+			continue
+		}
+		for _, err := range schema.Validate(actual) {
+			t.Errorf("%q: %v", test.src, err)
+		}
+
+		expected, err := parser.NewWithOptions(tokenizer.New(test.expected), test.options).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !parser.Equal(expected, actual, parser.CodeOptions) {
+			actualJson, _ := json.Marshal(actual)
+			t.Errorf("Invalid ast for %q.\ngot: %s", test.src, actualJson)
+		}
+	}
+
+	program, _ := parser.New(tokenizer.New("a + b \n")).Parse()
+	if statement := program["body"].([]parser.Node)[0]; statement.End() != 5 {
+		t.Errorf("Expected the statement to end after its expression, got: %d", statement.End())
+	}
+
+	for _, src := range []string{
+		"a b", "let a = 1 let b", "if (a) b else c", "function f() { return a b }",
+		"for (let a = 1\nb;;) {}", "for (;a\nb;) {}", "a++\nb",
+	} {
+		if _, err := parser.New(tokenizer.New(src)).Parse(); err == nil {
+			t.Errorf("Expected an error for %q", src)
+		}
+	}
+
+	if _, err := parser.NewWithOptions(tokenizer.New("do a; while (b) c"), parser.Options{EcmaVersion: 5}).Parse(); err == nil {
+		t.Error("Expected ES5 to require the ';' of a do-while statement")
+	}
+
+	// The ';' would be inserted before '++b', which the parser can't read
+	// rather than reading 'a + +b'.
+	_, err := parser.New(tokenizer.New("a\n++b")).Parse()
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Message != "'++' is not supported" || syntaxErr.Line != 2 {
+		t.Errorf("Expected '++' to be rejected on line 2, got: %v", err)
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	_, err := parser.New(tokenizer.New("x;\ny;\n+;")).Parse()
+
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("Expected a SyntaxError, got: %v", err)
+	}
+
+	if syntaxErr.Offset != 7 || syntaxErr.Line != 3 || syntaxErr.Column != 1 {
+		t.Errorf("Invalid error position. want: 7 (3:1) got: %d (%d:%d)", syntaxErr.Offset, syntaxErr.Line, syntaxErr.Column)
+	}
+	if syntaxErr.Found.Type != tokenizer.Semicolon {
+		t.Errorf("Invalid found token. want: %s got: %s", tokenizer.Semicolon, syntaxErr.Found.Type)
+	}
+	if syntaxErr.End != 8 {
+		t.Errorf("Invalid error end. want: 8 got: %d", syntaxErr.End)
+	}
+	if strings.Contains(err.Error(), "goroutine") {
+		t.Errorf("Expected no stack trace in the error, got: %v", err)
+	}
+
+	_, err = parser.New(tokenizer.New("x = (1;")).Parse()
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("Expected a SyntaxError, got: %v", err)
+	}
+
+	expected := []tokenizer.Type{tokenizer.ClosingParenthesis}
+	if !reflect.DeepEqual(syntaxErr.Expected, expected) || syntaxErr.Offset != 6 {
+		t.Errorf("Invalid error. want: %v at 6 got: %v at %d", expected, syntaxErr.Expected, syntaxErr.Offset)
+	}
+
+	_, err = parser.New(tokenizer.New("x;\n  #")).Parse()
+	if !errors.As(err, &syntaxErr) || syntaxErr.Line != 2 || syntaxErr.Column != 2 {
+		t.Errorf("Expected an invalid token error at 2:2, got: %v", err)
+	}
+	var lexErr *tokenizer.LexError
+	if !errors.As(err, &lexErr) || lexErr.Kind != tokenizer.UnknownCharacter {
+		t.Errorf("Expected the error to wrap a LexError, got: %v", err)
+	}
+
+	_, err = parser.New(tokenizer.New("let let = 1;")).Parse()
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 4 || syntaxErr.End != 7 {
+		t.Errorf("Expected an early error from 4 to 7, got: %v", err)
+	}
+}
+
+func TestNumericLiterals(t *testing.T) {
+	for src, expected := range map[string]int{`0;`: 0, `10;`: 10, `010;`: 8, `08;`: 8, `09;`: 9, `0719;`: 719} {
+		ast, err := parser.New(tokenizer.New(src)).Parse()
+		if err != nil {
+			t.Errorf("%s: %v", src, err)
+			continue
+		}
+
+		literal := parser.LiteralNode(ast["body"].([]parser.Node)[0]["expression"].(parser.Node))
+		if literal.Value() != expected {
+			t.Errorf("Unexpected value of %s. want: %d got: %v", src, expected, literal.Value())
+		}
+	}
+
+	_, err := parser.New(tokenizer.New("x = 99999999999999999999;")).Parse()
+
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 4 || syntaxErr.End != 24 || !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected a range error from 4 to 24, got: %v", err)
+	} else if syntaxErr.Message != "number out of range: 99999999999999999999" {
+		t.Errorf("Unexpected message: %s", syntaxErr.Message)
+	}
+}
+
+func TestUTF16Offsets(t *testing.T) {
+	src := "\"😀\";\n\"€\";"
+	ast, err := parser.New(tokenizer.NewWithEncoding(src, tokenizer.UTF16)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ast.End() != 10 {
+		t.Errorf("Invalid program end. want: 10 got: %d", ast.End())
+	}
+
+	options := parser.Options{Locations: true}
+	ast, err = parser.NewWithOptions(tokenizer.NewWithEncoding(`x = "😀" + y;`, tokenizer.UTF16), options).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	right := ast["body"].([]parser.Node)[0]["expression"].(parser.Node)["right"].(parser.Node)["right"].(parser.Node)
+	loc := right["loc"].(parser.SourceLocation)
+	if right.Start() != 11 || loc.Start != (tokenizer.Position{Line: 1, Column: 11}) {
+		t.Errorf("Invalid identifier position. want: 11 got: %d %v", right.Start(), loc.Start)
+	}
+}
+
+func TestNewDoesNotPanic(t *testing.T) {
+	_, err := parser.New(tokenizer.New(`#`)).Parse()
+
+	var syntaxErr *parser.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 0 {
+		t.Errorf("Expected a SyntaxError at 0, got: %v", err)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		`let x = 1;`,
+		`function f(a, b) { return a + b; }`,
+		`class A extends B { static m() { super.m(); } x = 1; }`,
+		`if (a) { b(); } else for (let i = 0; i < 1; i = i + 1) {}`,
+		`<a b="c" {...d}>e{f}<g/></a>;`,
+		`let x: Array<string> = y as any; interface I { a?: number; }`,
+		`@a class A { @b accessor x; }`,
+		`"\u{1F600}"; /* unterminated`,
+		strings.Repeat("!", 1200) + "x;",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, src string) {
+		options := parser.Options{JSX: true, TypeScript: true, Decorators: true, Locations: true}
+
+		parse := func(p parser.Parser) (ast parser.Node, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("Parse panicked: %v", r)
+				}
+			}()
+
+			return p.Parse()
+		}
+
+		for _, p := range []parser.Parser{
+			parser.New(tokenizer.New(src)),
+			parser.NewWithOptions(tokenizer.NewWithEncoding(src, tokenizer.UTF16), options),
+		} {
+			ast, err := parse(p)
+			if err == nil {
+				continue
+			}
+
+			if ast != nil {
+				t.Errorf("Expected no ast with error %v", err)
+			}
+
+			var syntaxErr *parser.SyntaxError
+			var errs parser.ErrorList
+			if !errors.As(err, &syntaxErr) && !errors.As(err, &errs) && !errors.Is(err, parser.ErrLimitExceeded) {
+				t.Errorf("Expected a SyntaxError, ErrorList or ErrLimitExceeded, got: %T %v", err, err)
+			}
+		}
+
+		options.Tolerant = true
+		ast, err := parse(parser.NewWithOptions(tokenizer.New(src), options))
+		// Limits stop the parser instead of being recovered from.
+		if ast == nil && !errors.Is(err, parser.ErrLimitExceeded) {
+			t.Errorf("Expected a partial ast in tolerant mode, got: %v", err)
+		}
+	})
+}
+
+func TestTolerant(t *testing.T) {
+	src := "let a = ;\nx y;\nfunction f() { return ) ; b = 1; }\n# c;"
+	ast, err := parser.NewWithOptions(tokenizer.New(src), parser.Options{Tolerant: true}).Parse()
+
+	var errs parser.ErrorList
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected an ErrorList, got: %v", err)
+	}
+
+	expectedErrors := [][2]int{{1, 8}, {2, 2}, {3, 22}, {4, 0}}
+	if len(errs) != len(expectedErrors) {
+		t.Fatalf("Unexpected errors. want: %v got: %v", expectedErrors, errs)
+	}
+	for i, position := range expectedErrors {
+		if errs[i].Line != position[0] || errs[i].Column != position[1] {
+			t.Errorf("Unexpected error position. want: %v got: %v", position, errs[i])
+		}
+	}
+
+	body := ast["body"].([]parser.Node)
+	expectedTypes := []parser.Type{parser.VariableDeclaration, parser.ErrorNode, parser.FunctionDeclaration, parser.ExpressionStatement}
+	if len(body) != len(expectedTypes) {
+		t.Fatalf("Unexpected statements. want: %v got: %v", expectedTypes, body)
+	}
+	for i, expected := range expectedTypes {
+		if body[i].Type() != expected {
+			t.Errorf("Unexpected statement %d. want: %s got: %s", i, expected, body[i].Type())
+		}
+	}
+
+	init := body[0]["declarations"].([]parser.Node)[0]["init"].(parser.Node)
+	if init.Type() != parser.Identifier || parser.IdentifierNode(init).Name() != "" || init.Start() != 8 || init.End() != 8 {
+		t.Errorf("Expected an empty placeholder identifier at 8, got: %v", init)
+	}
+
+	functionBody := body[2]["body"].(parser.Node)["body"].([]parser.Node)
+	if len(functionBody) != 2 || functionBody[0].Type() != parser.ErrorNode || functionBody[1].Type() != parser.ExpressionStatement {
+		t.Errorf("Expected the function body to recover after the error, got: %v", functionBody)
+	}
+
+	if _, err := parser.NewWithOptions(tokenizer.New("x;"), parser.Options{Tolerant: true}).Parse(); err != nil {
+		t.Errorf("Expected no error for a valid program in tolerant mode, got: %v", err)
+	}
+}
+
+func TestDeepNesting(t *testing.T) {
+	for _, src := range []string{
+		strings.Repeat("(", 100000) + "x" + strings.Repeat(")", 100000) + ";",
+		strings.Repeat("!", 100000) + "x;",
+		strings.Repeat("{", 100000) + strings.Repeat("}", 100000),
+	} {
+		// Tolerant mode doesn't recover from limits, and has no partial tree.
+		for _, options := range []parser.Options{{}, {Tolerant: true}} {
+			ast, err := parser.NewWithOptions(tokenizer.New(src), options).Parse()
+			if ast != nil || !errors.Is(err, parser.ErrLimitExceeded) {
+				t.Errorf("Expected deeply nested input to exceed the depth limit with %+v, got: %v", options, err)
+			}
+		}
+	}
+
+	src := strings.Repeat("(", 100) + "x" + strings.Repeat(")", 100) + ";"
+	if _, err := parser.New(tokenizer.New(src)).Parse(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	src := strings.Repeat("x = 1;\n", 100000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := parser.ParseContext(ctx, parser.New(tokenizer.New(src)))
+	var canceled *parser.CanceledError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &canceled) {
+		t.Errorf("Expected a canceled context to stop parsing, got: %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if _, err := parser.ParseContext(ctx, parser.NewWithOptions(tokenizer.New(src), parser.Options{Tolerant: true})); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected an expired deadline to stop parsing, got: %v", err)
+	}
+
+	if _, err := parser.ParseContext(context.Background(), parser.New(tokenizer.New(src))); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := parser.New(tokenizer.New(src)).(parser.ContextParser).ParseContext(nil); err != nil {
+		t.Errorf("Expected a nil context to never be done, got: %v", err)
+	}
+}
+
+// brokenTokenizer fails like a buggy tokenizer after a few tokens.
+type brokenTokenizer struct {
+	tokenizer.Tokenizer
+	tokens []tokenizer.Token
+}
+
+func (t *brokenTokenizer) Next() (tokenizer.Token, error) {
+	token, err := t.Tokenizer.Next()
+	t.tokens = append(t.tokens, token)
+	if len(t.tokens) == 3 {
+		return t.tokens[3], err
+	}
+
+	return token, err
+}
+
+func TestInternalError(t *testing.T) {
+	for _, options := range []parser.Options{{}, {Tolerant: true}} {
+		ast, err := parser.NewWithOptions(&brokenTokenizer{Tokenizer: tokenizer.New("x = (y);")}, options).Parse()
+
+		var internalErr *parser.InternalError
+		var runtimeErr runtime.Error
+		if ast != nil || !errors.As(err, &internalErr) || !errors.As(err, &runtimeErr) {
+			t.Errorf("Expected an InternalError with %+v, got: %v", options, err)
+			continue
+		}
+		if internalErr.Offset != 2 || len(internalErr.Stack) == 0 {
+			t.Errorf("Unexpected InternalError: %+v", internalErr)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		src      string
+		options  parser.Options
+		exceeded bool
+	}{
+		{`((x));`, parser.Options{MaxDepth: 7}, false},
+		{`((x));`, parser.Options{MaxDepth: 6}, true},
+		{`x = 1;`, parser.Options{MaxTokens: 5}, false},
+		{`x = 1;`, parser.Options{MaxTokens: 4}, true},
+		{`x = 1;`, parser.Options{MaxSourceBytes: 6}, false},
+		{`x = 1; `, parser.Options{MaxSourceBytes: 6}, true},
+		{`((x));`, parser.Options{MaxDepth: 5, Tolerant: true}, true},
+		{`f<((((x))))>(1);`, parser.Options{MaxDepth: 5, TypeScript: true}, true},
+	}
+
+	for _, test := range tests {
+		ast, err := parser.NewWithOptions(tokenizer.New(test.src), test.options).Parse()
+
+		if exceeded := errors.Is(err, parser.ErrLimitExceeded); exceeded != test.exceeded {
+			t.Errorf("Unexpected result for %q with %+v. want exceeded: %v got: %v", test.src, test.options, test.exceeded, err)
+		}
+		if test.exceeded && ast != nil {
+			t.Errorf("Expected no ast when a limit is exceeded for %q", test.src)
+		}
+	}
+
+	// Every recursive production counts towards MaxDepth.
+	n := parser.DefaultMaxDepth + 1
+	extensions := parser.Options{TypeScript: true, JSX: true, Decorators: true}
+	for _, src := range []string{
+		strings.Repeat("if (a) ", n) + "b;",
+		strings.Repeat("while (a) ", n) + "b;",
+		strings.Repeat("for (;;) ", n) + "b;",
+		strings.Repeat("do ", n) + "b;" + strings.Repeat(" while (a);", n),
+		strings.Repeat("function f() {", n) + strings.Repeat("}", n),
+		strings.Repeat("class A { m() {", n) + strings.Repeat("} }", n),
+		strings.Repeat("a = ", n) + "b;",
+		"f" + strings.Repeat("(f", n) + strings.Repeat(")", n) + ";",
+		"a" + strings.Repeat("[a", n) + strings.Repeat("]", n) + ";",
+		"f" + strings.Repeat("<A", n) + strings.Repeat(">", n) + "();",
+		"let x: " + strings.Repeat("A<", n) + "B" + strings.Repeat(">", n) + ";",
+		"let x: " + strings.Repeat("{ a: ", n) + "B" + strings.Repeat(" }", n) + ";",
+		"let x: " + strings.Repeat("[", n) + "B" + strings.Repeat("]", n) + ";",
+		"let x: " + strings.Repeat("() => ", n) + "B;",
+		"let x: " + strings.Repeat("keyof ", n) + "B;",
+		strings.Repeat("<a>{", n) + strings.Repeat("}</a>", n) + ";",
+		strings.Repeat("<a b=", n) + "<a/>" + strings.Repeat(" />", n) + ";",
+		"class A { " + strings.Repeat("@(", n) + "a" + strings.Repeat(")", n) + " m() {} }",
+	} {
+		if _, err := parser.NewWithOptions(tokenizer.New(src), extensions).Parse(); !errors.Is(err, parser.ErrLimitExceeded) {
+			t.Errorf("Expected %.20s... to exceed the depth limit, got: %v", src, err)
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	for _, src := range []string{`x.y['test'];`, `x.y = 2;`, `console.log('1235');`, `class Rectangle extends Drawable {}`} {
+		reference, err := cache(src, acornRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := parser.DecodeJSON(reference)
+		if err != nil {
+			t.Fatalf("%s: %v", src, err)
+		}
+
+		expected, _ := parser.New(tokenizer.New(src)).Parse()
+		if !reflect.DeepEqual(n, expected) {
+			t.Errorf("Invalid decoded ast of %s.\nwant: %#v\ngot: %#v", src, expected, n)
+		}
+	}
+
+	for _, src := range []string{`if (a) b = null;`, `function f() { return; }`, `for (;;) {}`, `class A {}`} {
+		expected, _ := parser.New(tokenizer.New(src)).Parse()
+		b, _ := json.Marshal(expected)
+
+		n, err := parser.DecodeJSON(b)
+		if err != nil {
+			t.Fatalf("%s: %v", src, err)
+		}
+		if !reflect.DeepEqual(n, expected) {
+			t.Errorf("Invalid decoded ast of %s.\nwant: %#v\ngot: %#v", src, expected, n)
+		}
+	}
+
+	src := `class A { m() { return 1; } }`
+	expected, _ := parser.NewWithOptions(tokenizer.New(src), parser.Options{Locations: true}).Parse()
+	b, _ := json.Marshal(expected)
+
+	var n parser.Node
+	if err := json.Unmarshal(b, &n); err != nil {
+		t.Fatal(err)
+	}
+
+	method := n["body"].([]parser.Node)[0]["body"].(parser.Node)["body"].([]parser.Node)[0]
+	if method.Type() != parser.MethodDefinition || method.Start() != 10 || method["kind"] != parser.Method {
+		t.Errorf("Unexpected method: %v", method)
+	}
+	if loc := method["loc"].(parser.SourceLocation); loc.End.Column != 27 {
+		t.Errorf("Unexpected location: %v", loc)
+	}
+
+	for _, invalid := range []string{
+		`[]`,
+		`{"start": 0, "end": 1}`,
+		`{"type": "Unknown", "start": 0, "end": 1}`,
+		`{"type": "Program", "start": 0, "end": 1, "body": [{"type": "ArrowFunctionExpression", "start": 0, "end": 1}]}`,
+		`{"type": "Identifier", "start": 0.5, "end": 1, "name": "a"}`,
+		`{"type": "Identifier", "start": 0, "end": 1, "name": "a"} {}`,
+	} {
+		if _, err := parser.DecodeJSON([]byte(invalid)); err == nil {
+			t.Errorf("Expected an error decoding %s", invalid)
+		}
+	}
+}
+
+func TestCloneAndEqual(t *testing.T) {
+	parse := func(src string) parser.Node {
+		n, err := parser.NewWithOptions(tokenizer.New(src), parser.Options{Locations: true}).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return n
+	}
+
+	a := parse(`x = f(1, "a");`)
+	clone := parser.Clone(a)
+	if !reflect.DeepEqual(a, clone) {
+		t.Error("Expected the clone to be deeply equal")
+	}
+
+	clone["body"].([]parser.Node)[0]["expression"].(parser.Node)["operator"] = "+="
+	if a["body"].([]parser.Node)[0]["expression"].(parser.Node)["operator"] != "=" {
+		t.Error("Expected the clone to share nothing")
+	}
+	if parser.Equal(a, clone, parser.CodeOptions) {
+		t.Error("Expected the changed clone to differ")
+	}
+
+	tests := []struct {
+		a        string
+		b        string
+		options  parser.EqualOptions
+		expected bool
+	}{
+		{`x = f(1, "a");`, `x = f(1, "a");`, parser.EqualOptions{}, true},
+		{`x = f(1, "a");`, `x  =  f(1,"a");`, parser.EqualOptions{}, false},
+		{`x = f(1, "a");`, `x  =  f(1,"a");`, parser.EqualOptions{IgnorePositions: true}, false},
+		{`x = f(1, "a");`, `x  =  f(1,"a");`, parser.EqualOptions{IgnorePositions: true, IgnoreLocations: true}, true},
+		{`x = f(1, "a");`, `x = f(1, 'a');`, parser.EqualOptions{IgnoreLocations: true}, false},
+		{`x = f(1, "a");`, `x = f(1, 'a');`, parser.CodeOptions, true},
+		{`x = f(1, "a");`, `x = f(1, "b");`, parser.CodeOptions, false},
+		{`x = f(1, "a");`, `x = f(1);`, parser.CodeOptions, false},
+		{`x = f(010);`, `x = f(8);`, parser.CodeOptions, true},
+		{`x = f(1);`, `x = g(1);`, parser.CodeOptions, false},
+	}
+
+	for _, test := range tests {
+		a, b := parse(test.a), parse(test.b)
+
+		if actual := parser.Equal(a, b, test.options); actual != test.expected {
+			t.Errorf("Expected Equal(%s, %s, %+v) to be %t", test.a, test.b, test.options, test.expected)
+		}
+		if test.expected && parser.Hash(a, test.options) != parser.Hash(b, test.options) {
+			t.Errorf("Expected equal hashes for %s and %s", test.a, test.b)
+		}
+		if !test.expected && parser.Hash(a, test.options) == parser.Hash(b, test.options) {
+			t.Errorf("Expected different hashes for %s and %s", test.a, test.b)
+		}
+	}
+
+	// Nodes decoded from JSON or built by hand may use other Go types.
+	decoded := parser.Node{
+		"type":           "Identifier",
+		"start":          0.0,
+		"end":            1,
+		"name":           "a",
+		"typeAnnotation": nil,
+		"decorators":     []interface{}{},
+	}
+	if !parser.Equal(parser.NewIdentifier(0, 1, "a"), decoded, parser.EqualOptions{}) {
+		t.Error("Expected a decoded identifier to equal a parsed one")
+	}
+	if parser.Hash(parser.NewIdentifier(0, 1, "a"), parser.EqualOptions{}) != parser.Hash(decoded, parser.EqualOptions{}) {
+		t.Error("Expected a decoded identifier to hash like a parsed one")
+	}
+}
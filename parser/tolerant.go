@@ -0,0 +1,84 @@
+package parser
+
+import "github.com/0xvesion/go-js-parser/tokenizer"
+
+// recordError keeps an error recovered from in tolerant mode. Only the first
+// error at an offset is kept, as the following ones are caused by recovering.
+func (p *parser) recordError(err *SyntaxError) {
+	if len(p.errors) > 0 && p.errors[len(p.errors)-1].Offset == err.Offset {
+		return
+	}
+
+	p.locate(err)
+	p.errors = append(p.errors, err)
+}
+
+// statementOrError parses a statement. In tolerant mode, a statement which
+// fails to parse is recorded, skipped and replaced by an ErrorNode.
+func (p *parser) statementOrError() (n Node) {
+	if !p.options.Tolerant {
+		return p.statement()
+	}
+
+	start := p.lookAhead.Start
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		} else if isAbort(r) {
+			panic(r)
+		}
+
+		p.recordError(p.syntaxError(r))
+		p.synchronize(start)
+
+		end := p.lookBehind.End
+		if end < start {
+			end = start
+		}
+
+		n = NewErrorNode(start, end)
+	}()
+
+	n = p.statement()
+	if p.lookAhead.Start == start && p.lookAhead.Not(tokenizer.None) {
+		// Placeholders and an inserted ';' read nothing, so this statement
+		// would be parsed again and again.
+		p.consume(tokenizer.Semicolon)
+	}
+
+	return n
+}
+
+// synchronize skips to the next statement boundary: past a ';', or before a
+// '}' closing the enclosing block. At least one token is skipped, so a
+// statement starting with an invalid token can't fail forever.
+func (p *parser) synchronize(start int) {
+	p.t.SetContext(tokenizer.DefaultContext)
+
+	if p.lookAhead.Start == start && p.lookAhead.Not(tokenizer.None) {
+		if p.consumeAny().Is(tokenizer.Semicolon) {
+			return
+		}
+	}
+
+	for p.lookAhead.Not(tokenizer.None, tokenizer.ClosingCurlyBrace) {
+		if p.consumeAny().Is(tokenizer.Semicolon) {
+			return
+		}
+	}
+}
+
+// isLookaheadExpressionEnd reports whether the lookahead can follow an
+// expression, so a missing expression can be replaced by a placeholder.
+func (p *parser) isLookaheadExpressionEnd() bool {
+	return p.lookAhead.Is(
+		tokenizer.None,
+		tokenizer.Semicolon,
+		tokenizer.Comma,
+		tokenizer.ClosingParenthesis,
+		tokenizer.ClosingBracket,
+		tokenizer.ClosingCurlyBrace,
+	)
+}
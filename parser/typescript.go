@@ -0,0 +1,742 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+var tsKeywordTypes = map[string]Type{
+	"any":       TSAnyKeyword,
+	"unknown":   TSUnknownKeyword,
+	"number":    TSNumberKeyword,
+	"object":    TSObjectKeyword,
+	"boolean":   TSBooleanKeyword,
+	"bigint":    TSBigIntKeyword,
+	"string":    TSStringKeyword,
+	"symbol":    TSSymbolKeyword,
+	"void":      TSVoidKeyword,
+	"undefined": TSUndefinedKeyword,
+	"never":     TSNeverKeyword,
+}
+
+var tsAccessibilityModifiers = map[string]bool{
+	"public":    true,
+	"private":   true,
+	"protected": true,
+}
+
+// tsDeclaration returns the TypeScript declaration starting at the lookahead,
+// or nil if there is none. Its keywords are contextual, so 'type = 1;' is
+// still an expression statement.
+func (p *parser) tsDeclaration() Node {
+	if p.lookAhead.Not(tokenizer.Identifier) {
+		return nil
+	}
+
+	next := p.peek()
+	switch p.lookAhead.Value {
+	case "interface":
+		if next.Is(tokenizer.Identifier) {
+			return p.tsInterfaceDeclaration()
+		}
+	case "type":
+		if next.Is(tokenizer.Identifier) {
+			return p.tsTypeAliasDeclaration()
+		}
+	case "enum":
+		if next.Is(tokenizer.Identifier) {
+			return p.tsEnumDeclaration()
+		}
+	case "abstract":
+		if next.Is(tokenizer.ClassKeyword) {
+			return p.tsAbstractClassDeclaration()
+		}
+	}
+
+	return nil
+}
+
+// AbstractClassDeclaration
+// 	: 'abstract' ClassDeclaration
+// 	;
+func (p *parser) tsAbstractClassDeclaration() Node {
+	start := p.consumeValue(tokenizer.Identifier, "abstract").Start
+
+	class := p.classDeclaration()
+	class["start"] = start
+	class["abstract"] = true
+
+	return class
+}
+
+// InterfaceDeclaration
+// 	: 'interface' Identifier OptTypeParameters OptInterfaceExtends InterfaceBody
+// 	;
+//
+// InterfaceExtends
+// 	: 'extends' HeritageList
+// 	;
+func (p *parser) tsInterfaceDeclaration() Node {
+	start := p.consumeValue(tokenizer.Identifier, "interface").Start
+	id := p.identifier()
+	typeParameters := p.tsOptTypeParameters()
+
+	extends := []Node{}
+	if p.lookAhead.Is(tokenizer.ExtendsKeyword) {
+		p.consume(tokenizer.ExtendsKeyword)
+		extends = p.tsHeritageList(NewTSInterfaceHeritage)
+	}
+
+	body := p.tsInterfaceBody()
+
+	return NewTSInterfaceDeclaration(start, body.End(), id, typeParameters, extends, body)
+}
+
+// HeritageList
+// 	: Heritage
+// 	| HeritageList ',' Heritage
+// 	;
+//
+// Heritage
+// 	: MemberExpression OptTypeArguments
+// 	;
+func (p *parser) tsHeritageList(newer func(int, int, Node, Node) Node) []Node {
+	list := []Node{}
+
+	for {
+		expression := p.memberExpression()
+		end := expression.End()
+
+		var typeArguments Node
+		if p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
+			typeArguments = p.tsTypeArguments()
+			end = typeArguments.End()
+		}
+
+		list = append(list, newer(expression.Start(), end, expression, typeArguments))
+
+		if p.lookAhead.Not(tokenizer.Comma) {
+			return list
+		}
+		p.consume(tokenizer.Comma)
+	}
+}
+
+// InterfaceBody
+// 	: '{' OptTypeMemberList '}'
+// 	;
+func (p *parser) tsInterfaceBody() Node {
+	start := p.lookAhead.Start
+	members := p.tsTypeMembers()
+
+	return NewTSInterfaceBody(start, p.lookBehind.End, members)
+}
+
+// TypeAliasDeclaration
+// 	: 'type' Identifier OptTypeParameters '=' Type ';'
+// 	;
+func (p *parser) tsTypeAliasDeclaration() Node {
+	start := p.consumeValue(tokenizer.Identifier, "type").Start
+	id := p.identifier()
+	typeParameters := p.tsOptTypeParameters()
+
+	p.consume(tokenizer.SimpleAssignmentOperator)
+	typeAnnotation := p.tsType()
+	end := p.semicolon()
+
+	return NewTSTypeAliasDeclaration(start, end, id, typeParameters, typeAnnotation)
+}
+
+// EnumDeclaration
+// 	: OptConst 'enum' Identifier '{' OptEnumMemberList '}'
+// 	;
+//
+// EnumMember
+// 	: Identifier OptInitializer
+// 	| StringLiteral OptInitializer
+// 	;
+func (p *parser) tsEnumDeclaration() Node {
+	start := p.lookAhead.Start
+	isConst := p.isLookaheadValue(tokenizer.VariableDeclarationKeyword, "const")
+	if isConst {
+		p.consume(tokenizer.VariableDeclarationKeyword)
+	}
+
+	p.consumeValue(tokenizer.Identifier, "enum")
+	id := p.identifier()
+
+	p.consume(tokenizer.OpeningCurlyBrace)
+	members := []Node{}
+	for p.lookAhead.Not(tokenizer.ClosingCurlyBrace) {
+		var memberId Node
+		if p.lookAhead.Is(tokenizer.String) {
+			memberId = p.stringLiteral()
+		} else {
+			memberId = p.identifier()
+		}
+
+		end := memberId.End()
+		var initializer Node
+		if p.lookAhead.Is(tokenizer.SimpleAssignmentOperator) {
+			p.consume(tokenizer.SimpleAssignmentOperator)
+			initializer = p.assignmentExpression()
+			end = initializer.End()
+		}
+
+		members = append(members, NewTSEnumMember(memberId.Start(), end, memberId, initializer))
+
+		if p.lookAhead.Not(tokenizer.Comma) {
+			break
+		}
+		p.consume(tokenizer.Comma)
+	}
+	end := p.consume(tokenizer.ClosingCurlyBrace).End
+
+	return NewTSEnumDeclaration(start, end, id, members, isConst)
+}
+
+// tsOptTypeAnnotation parses a type annotation if the lookahead starts one.
+func (p *parser) tsOptTypeAnnotation() Node {
+	if !p.options.TypeScript || p.lookAhead.Not(tokenizer.Colon) {
+		return nil
+	}
+
+	return p.tsTypeAnnotation()
+}
+
+// TypeAnnotation
+// 	: ':' Type
+// 	;
+func (p *parser) tsTypeAnnotation() Node {
+	start := p.consume(tokenizer.Colon).Start
+	typeAnnotation := p.tsType()
+
+	return NewTSTypeAnnotation(start, typeAnnotation.End(), typeAnnotation)
+}
+
+// tsAnnotateBinding parses the optional marker and type annotation following
+// a binding identifier, which both become part of the identifier.
+func (p *parser) tsAnnotateBinding(id Node) Node {
+	if !p.options.TypeScript {
+		return id
+	}
+
+	if p.lookAhead.Is(tokenizer.QuestionMark) {
+		id["optional"] = true
+		id.SetEnd(p.consume(tokenizer.QuestionMark).End)
+	}
+
+	if typeAnnotation := p.tsOptTypeAnnotation(); typeAnnotation != nil {
+		id["typeAnnotation"] = typeAnnotation
+		id.SetEnd(typeAnnotation.End())
+	}
+
+	return id
+}
+
+// tsOptTypeParameters parses type parameters if the lookahead starts them.
+func (p *parser) tsOptTypeParameters() Node {
+	if !p.options.TypeScript || !p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
+		return nil
+	}
+
+	return p.tsTypeParameters()
+}
+
+// TypeParameters
+// 	: '<' TypeParameterList '>'
+// 	;
+//
+// TypeParameter
+// 	: Identifier OptConstraint OptDefault
+// 	;
+func (p *parser) tsTypeParameters() Node {
+	start := p.consumeValue(tokenizer.RelationalOperator, "<").Start
+
+	params := []Node{}
+	for {
+		name := p.identifier()
+		end := name.End()
+
+		var constraint Node
+		if p.lookAhead.Is(tokenizer.ExtendsKeyword) {
+			p.consume(tokenizer.ExtendsKeyword)
+			constraint = p.tsType()
+			end = constraint.End()
+		}
+
+		var defaultType Node
+		if p.lookAhead.Is(tokenizer.SimpleAssignmentOperator) {
+			p.consume(tokenizer.SimpleAssignmentOperator)
+			defaultType = p.tsType()
+			end = defaultType.End()
+		}
+
+		params = append(params, NewTSTypeParameter(name.Start(), end, name, constraint, defaultType))
+
+		if p.lookAhead.Not(tokenizer.Comma) {
+			break
+		}
+		p.consume(tokenizer.Comma)
+	}
+
+	end := p.consumeValue(tokenizer.RelationalOperator, ">").End
+
+	return NewTSTypeParameterDeclaration(start, end, params)
+}
+
+// TypeArguments
+// 	: '<' TypeList '>'
+// 	;
+func (p *parser) tsTypeArguments() Node {
+	start := p.consumeValue(tokenizer.RelationalOperator, "<").Start
+	params := p.tsTypeList()
+	end := p.consumeValue(tokenizer.RelationalOperator, ">").End
+
+	return NewTSTypeParameterInstantiation(start, end, params)
+}
+
+// TypeList
+// 	: Type
+// 	| TypeList ',' Type
+// 	;
+func (p *parser) tsTypeList() []Node {
+	types := []Node{p.tsType()}
+
+	for p.lookAhead.Is(tokenizer.Comma) {
+		p.consume(tokenizer.Comma)
+		types = append(types, p.tsType())
+	}
+
+	return types
+}
+
+// Type
+// 	: FunctionType
+// 	| UnionType
+// 	;
+func (p *parser) tsType() Node {
+	p.enter()
+	defer p.leave()
+
+	if p.lookAhead.Is(tokenizer.OpeningParenthesis) || p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
+		if functionType, ok := p.try(p.tsFunctionType); ok {
+			return functionType
+		}
+	}
+
+	return p.tsUnionType()
+}
+
+// FunctionType
+// 	: OptTypeParameters '(' OptParameterList ')' '=>' Type
+// 	;
+func (p *parser) tsFunctionType() Node {
+	start := p.lookAhead.Start
+	typeParameters := p.tsOptTypeParameters()
+
+	p.consume(tokenizer.OpeningParenthesis)
+	params := []Node{}
+	if p.lookAhead.Not(tokenizer.ClosingParenthesis) {
+		params = p.parameterList()
+	}
+	p.consume(tokenizer.ClosingParenthesis)
+
+	arrow := p.consume(tokenizer.Arrow)
+	returnType := p.tsType()
+
+	return NewTSFunctionType(
+		start,
+		returnType.End(),
+		typeParameters,
+		params,
+		NewTSTypeAnnotation(arrow.Start, returnType.End(), returnType),
+	)
+}
+
+// UnionType
+// 	: OptPipe IntersectionType
+// 	| UnionType '|' IntersectionType
+// 	;
+func (p *parser) tsUnionType() Node {
+	return p.tsCompositeType(tokenizer.BitwiseOrOperator, p.tsIntersectionType, NewTSUnionType)
+}
+
+// IntersectionType
+// 	: OptAmpersand TypeOperator
+// 	| IntersectionType '&' TypeOperator
+// 	;
+func (p *parser) tsIntersectionType() Node {
+	return p.tsCompositeType(tokenizer.BitwiseAndOperator, p.tsTypeOperator, NewTSIntersectionType)
+}
+
+// tsCompositeType parses a list of types separated by an operator. A leading
+// operator is allowed and always produces a composite type, as in TypeScript.
+func (p *parser) tsCompositeType(
+	operator tokenizer.Type,
+	builder func() Node,
+	newer func(int, int, []Node) Node,
+) Node {
+	start := p.lookAhead.Start
+	leading := p.lookAhead.Is(operator)
+	if leading {
+		p.consume(operator)
+	}
+
+	types := []Node{builder()}
+	for p.lookAhead.Is(operator) {
+		p.consume(operator)
+		types = append(types, builder())
+	}
+
+	if len(types) == 1 && !leading {
+		return types[0]
+	}
+
+	return newer(start, types[len(types)-1].End(), types)
+}
+
+// TypeOperator
+// 	: 'keyof' TypeOperator
+// 	| ArrayType
+// 	;
+func (p *parser) tsTypeOperator() Node {
+	if !p.isLookaheadValue(tokenizer.Identifier, "keyof") {
+		return p.tsArrayType()
+	}
+
+	p.enter()
+	defer p.leave()
+
+	start := p.consume(tokenizer.Identifier).Start
+	typeAnnotation := p.tsTypeOperator()
+
+	return NewTSTypeOperator(start, typeAnnotation.End(), "keyof", typeAnnotation)
+}
+
+// ArrayType
+// 	: PrimaryType
+// 	| ArrayType '[' ']'
+// 	| ArrayType '[' Type ']'
+// 	;
+func (p *parser) tsArrayType() Node {
+	t := p.tsPrimaryType()
+
+	for p.lookAhead.Is(tokenizer.OpeningBracket) {
+		p.consume(tokenizer.OpeningBracket)
+
+		if p.lookAhead.Is(tokenizer.ClosingBracket) {
+			end := p.consume(tokenizer.ClosingBracket).End
+			t = NewTSArrayType(t.Start(), end, t)
+
+			continue
+		}
+
+		index := p.tsType()
+		end := p.consume(tokenizer.ClosingBracket).End
+		t = NewTSIndexedAccessType(t.Start(), end, t, index)
+	}
+
+	return t
+}
+
+// PrimaryType
+// 	: '(' Type ')'
+// 	| TypeLiteral
+// 	| TupleType
+// 	| LiteralType
+// 	| KeywordType
+// 	| 'null'
+// 	| 'this'
+// 	| TypeReference
+// 	;
+func (p *parser) tsPrimaryType() Node {
+	switch p.lookAhead.Type {
+	case tokenizer.OpeningParenthesis:
+		p.consume(tokenizer.OpeningParenthesis)
+		t := p.tsType()
+		p.consume(tokenizer.ClosingParenthesis)
+
+		return t
+	case tokenizer.OpeningCurlyBrace:
+		start := p.lookAhead.Start
+		members := p.tsTypeMembers()
+
+		return NewTSTypeLiteral(start, p.lookBehind.End, members)
+	case tokenizer.OpeningBracket:
+		return p.tsTupleType()
+	case tokenizer.String, tokenizer.Number, tokenizer.BooleanLiteral:
+		literal := p.literal()
+
+		return NewTSLiteralType(literal.Start(), literal.End(), literal)
+	case tokenizer.AdditiveOperator:
+		operator := p.consumeValue(tokenizer.AdditiveOperator, "-")
+		number := p.numericLiteral()
+		literal := NewUnaryExpression(operator.Start, number.End(), operator.Value, number)
+
+		return NewTSLiteralType(literal.Start(), literal.End(), literal)
+	case tokenizer.NullLiteral:
+		token := p.consume(tokenizer.NullLiteral)
+
+		return NewTSKeyword(TSNullKeyword, token.Start, token.End)
+	case tokenizer.ThisKeyword:
+		token := p.consume(tokenizer.ThisKeyword)
+
+		return NewTSKeyword(TSThisType, token.Start, token.End)
+	case tokenizer.Identifier:
+		if keyword, ok := tsKeywordTypes[p.lookAhead.Value]; ok && p.peek().Not(tokenizer.Dot) {
+			token := p.consume(tokenizer.Identifier)
+
+			return NewTSKeyword(keyword, token.Start, token.End)
+		}
+
+		return p.tsTypeReference()
+	}
+
+	panic(fmt.Errorf("invalid type: %s", p.lookAhead.Type))
+}
+
+// TupleType
+// 	: '[' OptTypeList ']'
+// 	;
+func (p *parser) tsTupleType() Node {
+	start := p.consume(tokenizer.OpeningBracket).Start
+
+	elementTypes := []Node{}
+	if p.lookAhead.Not(tokenizer.ClosingBracket) {
+		elementTypes = p.tsTypeList()
+	}
+
+	end := p.consume(tokenizer.ClosingBracket).End
+
+	return NewTSTupleType(start, end, elementTypes)
+}
+
+// TypeReference
+// 	: EntityName OptTypeArguments
+// 	;
+//
+// EntityName
+// 	: Identifier
+// 	| EntityName '.' Identifier
+// 	;
+func (p *parser) tsTypeReference() Node {
+	name := p.identifier()
+	for p.lookAhead.Is(tokenizer.Dot) {
+		p.consume(tokenizer.Dot)
+		right := p.identifier()
+
+		name = NewTSQualifiedName(name.Start(), right.End(), name, right)
+	}
+
+	end := name.End()
+	var typeArguments Node
+	if p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
+		typeArguments = p.tsTypeArguments()
+		end = typeArguments.End()
+	}
+
+	return NewTSTypeReference(name.Start(), end, name, typeArguments)
+}
+
+// TypeMembers
+// 	: '{' OptTypeMemberList '}'
+// 	;
+func (p *parser) tsTypeMembers() []Node {
+	p.consume(tokenizer.OpeningCurlyBrace)
+
+	members := []Node{}
+	for p.lookAhead.Not(tokenizer.ClosingCurlyBrace) {
+		members = append(members, p.tsTypeMember())
+	}
+
+	p.consume(tokenizer.ClosingCurlyBrace)
+
+	return members
+}
+
+// TypeMember
+// 	: OptReadonly PropertySignature OptSeparator
+// 	| OptReadonly IndexSignature OptSeparator
+// 	| MethodSignature OptSeparator
+// 	;
+//
+// PropertySignature
+// 	: Identifier OptQuestionMark OptTypeAnnotation
+// 	;
+//
+// MethodSignature
+// 	: Identifier OptQuestionMark OptTypeParameters '(' OptParameterList ')' OptTypeAnnotation
+// 	;
+//
+// IndexSignature
+// 	: '[' Identifier TypeAnnotation ']' TypeAnnotation
+// 	;
+func (p *parser) tsTypeMember() Node {
+	start := p.lookAhead.Start
+
+	readonly := false
+	if p.isLookaheadValue(tokenizer.Identifier, "readonly") && p.peek().Is(tokenizer.Identifier, tokenizer.OpeningBracket) {
+		p.consume(tokenizer.Identifier)
+		readonly = true
+	}
+
+	if p.lookAhead.Is(tokenizer.OpeningBracket) {
+		p.consume(tokenizer.OpeningBracket)
+		parameter := p.identifier()
+		parameterType := p.tsTypeAnnotation()
+		parameter["typeAnnotation"] = parameterType
+		parameter.SetEnd(parameterType.End())
+		end := p.consume(tokenizer.ClosingBracket).End
+
+		typeAnnotation := p.tsOptTypeAnnotation()
+		if typeAnnotation != nil {
+			end = typeAnnotation.End()
+		}
+		end = p.tsTypeMemberSeparator(end)
+
+		return NewTSIndexSignature(start, end, []Node{parameter}, typeAnnotation, readonly)
+	}
+
+	key := p.identifier()
+	end := key.End()
+
+	optional := p.lookAhead.Is(tokenizer.QuestionMark)
+	if optional {
+		end = p.consume(tokenizer.QuestionMark).End
+	}
+
+	if !readonly && (p.lookAhead.Is(tokenizer.OpeningParenthesis) || p.isLookaheadValue(tokenizer.RelationalOperator, "<")) {
+		typeParameters := p.tsOptTypeParameters()
+
+		p.consume(tokenizer.OpeningParenthesis)
+		params := []Node{}
+		if p.lookAhead.Not(tokenizer.ClosingParenthesis) {
+			params = p.parameterList()
+		}
+		end = p.consume(tokenizer.ClosingParenthesis).End
+
+		returnType := p.tsOptTypeAnnotation()
+		if returnType != nil {
+			end = returnType.End()
+		}
+		end = p.tsTypeMemberSeparator(end)
+
+		return NewTSMethodSignature(start, end, key, optional, typeParameters, params, returnType)
+	}
+
+	typeAnnotation := p.tsOptTypeAnnotation()
+	if typeAnnotation != nil {
+		end = typeAnnotation.End()
+	}
+	end = p.tsTypeMemberSeparator(end)
+
+	return NewTSPropertySignature(start, end, key, optional, readonly, typeAnnotation)
+}
+
+// tsTypeMemberSeparator consumes the ';' or ',' ending a type member, which
+// is part of the member. It may be left out like the ';' of a statement.
+func (p *parser) tsTypeMemberSeparator(end int) int {
+	if p.lookAhead.Is(tokenizer.Semicolon, tokenizer.Comma) {
+		return p.consumeAny().End
+	}
+
+	if !p.canInsertSemicolon() {
+		panic(fmt.Errorf("unexpected token type. want: %s got: %s", tokenizer.Semicolon, p.lookAhead.Type))
+	}
+
+	return end
+}
+
+// ParameterProperty
+// 	: ParameterModifierList BindingIdentifier OptTypeAnnotation
+// 	;
+func (p *parser) tsParameterProperty() Node {
+	start := p.lookAhead.Start
+	accessibility := ""
+	readonly := false
+	override := false
+
+	for p.isLookaheadParameterModifier() {
+		modifier := p.consume(tokenizer.Identifier).Value
+		switch {
+		case tsAccessibilityModifiers[modifier]:
+			accessibility = modifier
+		case modifier == "readonly":
+			readonly = true
+		case modifier == "override":
+			override = true
+		}
+	}
+
+	parameter := p.tsAnnotateBinding(p.bindingIdentifier())
+
+	return NewTSParameterProperty(start, parameter.End(), accessibility, readonly, override, parameter)
+}
+
+func (p *parser) isLookaheadParameterModifier() bool {
+	if !p.options.TypeScript || p.lookAhead.Not(tokenizer.Identifier) {
+		return false
+	}
+
+	value := p.lookAhead.Value
+	if !tsAccessibilityModifiers[value] && value != "readonly" && value != "override" {
+		return false
+	}
+
+	return p.peek().Is(tokenizer.Identifier)
+}
+
+// TypeAssertion
+// 	: 'as' Type
+// 	| 'as' 'const'
+// 	| 'satisfies' Type
+// 	;
+func (p *parser) tsTypeAssertion(start int, expression Node) Node {
+	operator := p.consume(tokenizer.Identifier).Value
+
+	var typeAnnotation Node
+	if operator == "as" && p.isLookaheadValue(tokenizer.VariableDeclarationKeyword, "const") {
+		token := p.consume(tokenizer.VariableDeclarationKeyword)
+		typeName := NewIdentifier(token.Start, token.End, token.Value)
+		typeAnnotation = NewTSTypeReference(token.Start, token.End, typeName, nil)
+	} else {
+		typeAnnotation = p.tsType()
+	}
+
+	if operator == "satisfies" {
+		return NewTSSatisfiesExpression(start, typeAnnotation.End(), expression, typeAnnotation)
+	}
+
+	return NewTSAsExpression(start, typeAnnotation.End(), expression, typeAnnotation)
+}
+
+func (p *parser) isLookaheadTypeAssertion() bool {
+	return p.options.TypeScript &&
+		(p.isLookaheadValue(tokenizer.Identifier, "as") || p.isLookaheadValue(tokenizer.Identifier, "satisfies"))
+}
+
+func (p *parser) isLookaheadNonNull() bool {
+	return p.options.TypeScript && p.lookAhead.Is(tokenizer.LogicalNotOperator)
+}
+
+// tsOptCallTypeArguments parses the type arguments of a call such as
+// 'f<T>()'. A '<' not followed by type arguments and '(' is a relational
+// operator, so it backtracks in that case.
+func (p *parser) tsOptCallTypeArguments() Node {
+	if !p.options.TypeScript || !p.isLookaheadValue(tokenizer.RelationalOperator, "<") {
+		return nil
+	}
+
+	typeArguments, _ := p.try(func() Node {
+		typeArguments := p.tsTypeArguments()
+		if p.lookAhead.Not(tokenizer.OpeningParenthesis) {
+			panic(fmt.Errorf("not a call"))
+		}
+
+		return typeArguments
+	})
+
+	return typeArguments
+}
@@ -0,0 +1,203 @@
+package printer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+// comment is a comment of the source, including its delimiters.
+type comment struct {
+	start, end int
+	text       string
+}
+
+func (c comment) isLine() bool {
+	return strings.HasPrefix(c.text, "//")
+}
+
+// findComments returns the comments of the source a tree was parsed from.
+// The parser skips comments, so the source is scanned for them, outside of
+// the string literals and JSX text the tree points out.
+func findComments(src string, n parser.Node) []comment {
+	opaque := map[int]int{}
+	walk.Inspect(n, func(n parser.Node) bool {
+		if n.Is(parser.Literal) && isString(n) || n.Is(parser.JSXText) {
+			opaque[n.Start()] = n.End()
+		}
+
+		return true
+	})
+
+	comments := []comment{}
+	for i := 0; i < len(src); i++ {
+		if end, ok := opaque[i]; ok && end > i {
+			i = end - 1
+			continue
+		}
+
+		if !strings.HasPrefix(src[i:], "//") && !strings.HasPrefix(src[i:], "/*") {
+			continue
+		}
+
+		end := len(src)
+		if src[i+1] == '/' {
+			if j := strings.IndexAny(src[i:], "\r\n\u2028\u2029"); j != -1 {
+				end = i + j
+			}
+		} else if j := strings.Index(src[i+2:], "*/"); j != -1 {
+			end = i + 2 + j + 2
+		}
+
+		comments = append(comments, comment{i, end, src[i:end]})
+		i = end - 1
+	}
+
+	return comments
+}
+
+var blankLine = regexp.MustCompile(`\n[ \t\r]*\n`)
+
+// hasBlankLine reports whether the source has an empty line between two
+// offsets.
+func (p *printer) hasBlankLine(from, to int) bool {
+	if from < 0 || from >= to || to > len(p.options.Source) {
+		return false
+	}
+
+	return blankLine.MatchString(p.options.Source[from:to])
+}
+
+// skipPrinted moves next past the printed comments.
+func (p *printer) skipPrinted() {
+	for p.next < len(p.comments) && p.done[p.next] {
+		p.next++
+	}
+}
+
+// mark records a comment as printed.
+func (p *printer) mark(i int) {
+	p.done[i] = true
+	p.printed = append(p.printed, i)
+}
+
+// unprinted returns the indices of the comments which start before pos and
+// aren't printed yet.
+func (p *printer) unprinted(pos int) []int {
+	p.skipPrinted()
+
+	indices := []int{}
+	for i := p.next; i < len(p.comments) && p.comments[i].start < pos; i++ {
+		if !p.done[i] {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// inlineComments prints the block comments before pos at the start of an
+// expression or type. Line comments can't be followed by the rest of the
+// expression, so they are left for the end of the line.
+func (p *printer) inlineComments(pos int) {
+	for _, i := range p.unprinted(pos) {
+		if c := p.comments[i]; !c.isLine() {
+			p.mark(i)
+			p.print(c.text)
+			p.space()
+		}
+	}
+}
+
+// innerComments prints the comments before pos at the end of a JSX
+// expression container, where each line comment ends its line.
+func (p *printer) innerComments(pos int, space bool) {
+	for _, i := range p.unprinted(pos) {
+		c := p.comments[i]
+		p.mark(i)
+		if space {
+			p.space()
+		}
+		p.print(c.text)
+		if space = !c.isLine(); !space {
+			p.newline()
+		}
+	}
+}
+
+// lines prints the elements of a statement or member list on their own lines,
+// with the comments before, after and between them and single blank lines
+// where the source has some. end is the offset where the list ends in the
+// source. The caller starts the first line.
+func (p *printer) lines(items []parser.Node, end int, print func(i int)) {
+	prev, first := -1, true
+	line := func(start int) {
+		if !first {
+			if p.hasBlankLine(prev, start) {
+				p.print("\n")
+			}
+			p.newline()
+		}
+		first = false
+	}
+
+	standalone := func(pos int) {
+		for _, i := range p.unprinted(pos) {
+			c := p.comments[i]
+			p.mark(i)
+			line(c.start)
+			p.print(c.text)
+			prev = c.end
+		}
+	}
+
+	lineEnds := p.lineEnds
+	defer func() {
+		p.lineEnds = lineEnds
+	}()
+
+	for i, n := range items {
+		standalone(n.Start())
+
+		line(n.Start())
+		p.lineEnds = true
+		print(i)
+		prev = n.End()
+
+		p.trailingComments(&prev, line)
+	}
+
+	standalone(end)
+}
+
+// trailingComments prints the comments left inside the element of a list
+// which ended at *prev, and the ones following it on the same line.
+func (p *printer) trailingComments(prev *int, line func(int)) {
+	p.skipPrinted()
+
+	afterLine := false
+	for i := p.next; i < len(p.comments); i++ {
+		c := p.comments[i]
+		if p.done[i] {
+			continue
+		} else if c.start >= *prev && strings.Trim(p.options.Source[*prev:c.start], " \t,;") != "" {
+			return
+		}
+
+		p.mark(i)
+		if afterLine {
+			line(c.start)
+		} else {
+			p.space()
+		}
+		p.print(c.text)
+		*prev, afterLine = c.end, c.isLine()
+	}
+}
+
+// hasComments reports whether there are unprinted comments before pos.
+func (p *printer) hasComments(pos int) bool {
+	return len(p.unprinted(pos)) > 0
+}
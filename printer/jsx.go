@@ -0,0 +1,112 @@
+package printer
+
+import "github.com/0xvesion/go-js-parser/parser"
+
+// jsx prints JSX nodes. Children are printed as they are, since the
+// whitespace of JSXText is part of the tree.
+func (p *printer) jsx(n parser.Node) {
+	p.start(n)
+
+	switch n.Type() {
+	case parser.JSXElement:
+		p.jsx(child(n, "openingElement"))
+		p.jsxChildren(n)
+		if closing := child(n, "closingElement"); closing != nil {
+			p.jsx(closing)
+		}
+	case parser.JSXFragment:
+		p.print("<>")
+		p.jsxChildren(n)
+		p.print("</>")
+	case parser.JSXOpeningElement:
+		p.print("<")
+		p.jsx(child(n, "name"))
+
+		attributes := list(n, "attributes")
+		p.group(func(broken bool) {
+			if broken {
+				p.indent++
+			}
+			for _, attribute := range attributes {
+				if broken {
+					p.newline()
+				} else {
+					p.space()
+				}
+				p.jsx(attribute)
+			}
+			if broken {
+				p.indent--
+				p.newline()
+			}
+
+			switch {
+			case !flag(n, "selfClosing"):
+				p.print(">")
+			case broken:
+				p.print("/>")
+			default:
+				p.space()
+				p.print("/>")
+			}
+		})
+	case parser.JSXClosingElement:
+		p.print("</")
+		p.jsx(child(n, "name"))
+		p.print(">")
+	case parser.JSXAttribute:
+		p.jsx(child(n, "name"))
+		if value := child(n, "value"); value != nil {
+			p.print("=")
+			if raw, ok := value["raw"].(string); ok && value.Is(parser.Literal) {
+				// JSX strings have no escapes to change quotes with.
+				p.print(raw)
+			} else if value.Is(parser.Literal) {
+				p.literal(value)
+			} else {
+				p.jsx(value)
+			}
+		}
+	case parser.JSXSpreadAttribute:
+		p.print("{...")
+		p.expression(child(n, "argument"), precAssignment)
+		p.print("}")
+	case parser.JSXExpressionContainer:
+		p.print("{")
+		expression := child(n, "expression")
+		empty := expression.Is(parser.JSXEmptyExpression)
+		if !empty {
+			p.expression(expression, precAssignment)
+		}
+		p.innerComments(n.End(), !empty)
+		p.print("}")
+	case parser.JSXSpreadChild:
+		p.print("{...")
+		p.expression(child(n, "expression"), precAssignment)
+		p.print("}")
+	case parser.JSXText:
+		if raw, ok := n["raw"].(string); ok {
+			p.print(raw)
+		} else {
+			p.print(str(n, "value"))
+		}
+	case parser.JSXIdentifier:
+		p.print(str(n, "name"))
+	case parser.JSXMemberExpression:
+		p.jsx(child(n, "object"))
+		p.print(".")
+		p.jsx(child(n, "property"))
+	case parser.JSXNamespacedName:
+		p.jsx(child(n, "namespace"))
+		p.print(":")
+		p.jsx(child(n, "name"))
+	default:
+		p.errorf("cannot print %s", n.Type())
+	}
+}
+
+func (p *printer) jsxChildren(n parser.Node) {
+	for _, c := range list(n, "children") {
+		p.jsx(c)
+	}
+}
@@ -0,0 +1,1233 @@
+// Package printer turns trees back into source code. It prints every node
+// type the parser creates, with only the parentheses the precedence of the
+// operators requires:
+//
+//	src, err := printer.Print(program, printer.DefaultOptions)
+//
+// Printing a parsed program and parsing the result gives the same tree,
+// except for positions and parentheses.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0xvesion/go-js-parser/parser"
+)
+
+// Options configure the printed code.
+type Options struct {
+	// Indent is the string for one level of indentation.
+	Indent string
+	// Width is the line width the printer keeps to by putting the elements
+	// of argument, parameter, declarator, attribute and type member lists on
+	// their own lines. Zero never breaks lists.
+	Width int
+	// Quote is the quote of string literals, '"' or '\''. Strings
+	// containing a quote keep theirs. Zero prints strings as they are.
+	Quote byte
+	// Semicolons tells which statements end with a semicolon.
+	Semicolons Semicolons
+	// Source is the code the tree was parsed from, with offsets in bytes.
+	// The comments of the source are then printed along with the nodes
+	// around them, and single blank lines between statements and members
+	// are kept.
+	Source string
+	// Compact leaves out the whitespace the code doesn't need, the line
+	// breaks and the semicolons before a '}' or the end of the code. Width,
+	// Indent and the comments of Source are ignored.
+	Compact bool
+	// OnNode is called once the code is printed, with every node and the
+	// byte offset in the code where its first token is, in the order of the
+	// code. Nodes printed without tokens of their own aren't reported.
+	OnNode func(n parser.Node, offset int)
+}
+
+// Semicolons is a policy for the semicolons ending statements.
+type Semicolons int
+
+const (
+	// SemicolonsAlways ends every statement with a semicolon.
+	SemicolonsAlways Semicolons = iota
+	// SemicolonsAsNeeded leaves out the semicolons which automatic semicolon
+	// insertion puts back at the end of a line, and starts the statements
+	// which would continue the previous line with one.
+	SemicolonsAsNeeded
+)
+
+// DefaultOptions indent with two spaces.
+var DefaultOptions = Options{Indent: "  "}
+
+// Print returns the source code of a node, which is usually a Program but
+// may be any statement, expression or type.
+func Print(n parser.Node, options Options) (src string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			printErr, ok := r.(*printError)
+			if !ok {
+				panic(r)
+			}
+
+			src, err = "", printErr.err
+		}
+	}()
+
+	p := &printer{options: options, lineEnds: true}
+	if options.Source != "" && !options.Compact {
+		p.comments = findComments(options.Source, n)
+		p.done = make([]bool, len(p.comments))
+	}
+	p.node(n)
+
+	if options.OnNode != nil {
+		for _, start := range p.starts {
+			options.OnNode(start.n, start.offset)
+		}
+	}
+
+	return p.out.String(), nil
+}
+
+// Fprint writes the source code of a node to w.
+func Fprint(w io.Writer, n parser.Node, options Options) error {
+	src, err := Print(n, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, src)
+
+	return err
+}
+
+// printError is the value the printer panics with for trees it can't print,
+// like the parser does for invalid source.
+type printError struct {
+	err error
+}
+
+type printer struct {
+	options Options
+	out     bytes.Buffer
+	indent  int
+	// measuring is set while a group is printed on one line to see whether
+	// it fits, until the line ends.
+	measuring bool
+	// lineEnds is set while printing a statement at the end of a line, whose
+	// semicolon may be left out.
+	lineEnds bool
+	comments []comment
+	// done tells which comments are printed, and printed lists them in the
+	// order they were printed in, so a group can take them back.
+	done    []bool
+	printed []int
+	// next is the first comment which isn't printed.
+	next int
+	// omitted is the length of the output after the last semicolon left
+	// out.
+	omitted int
+	// In compact code, spaced is set after a space which is only printed if
+	// the tokens around it would run together, and ended is the length of
+	// the output after the last semicolon ending a statement.
+	spaced bool
+	ended  int
+	// starting are the nodes starting at the next token, and starts where
+	// the printed nodes start, for OnNode.
+	starting []parser.Node
+	starts   []nodeStart
+}
+
+type nodeStart struct {
+	n      parser.Node
+	offset int
+}
+
+// noFit is the value a group panics with once it doesn't fit on its line.
+type noFit struct{}
+
+func (p *printer) errorf(format string, args ...interface{}) {
+	panic(&printError{fmt.Errorf(format, args...)})
+}
+
+func (p *printer) print(s string) {
+	if p.options.Compact && s != "" {
+		b := p.out.Bytes()
+		if p.spaced && len(b) > 0 && runTogether(b[len(b)-1], s[0]) {
+			p.out.WriteByte(' ')
+		} else if s[0] == '}' && p.ended == len(b) && len(b) > 0 {
+			p.out.Truncate(len(b) - 1)
+			p.ended = -1
+		}
+		p.spaced = false
+	}
+
+	if len(p.starting) > 0 && s != "" && s != " " {
+		for _, n := range p.starting {
+			p.starts = append(p.starts, nodeStart{n, p.out.Len()})
+		}
+		p.starting = p.starting[:0]
+	}
+
+	p.out.WriteString(s)
+
+	if p.measuring && p.column() > p.options.Width {
+		panic(noFit{})
+	}
+}
+
+// start records that a node starts at the next token.
+func (p *printer) start(n parser.Node) {
+	if p.options.OnNode != nil {
+		p.starting = append(p.starting, n)
+	}
+}
+
+func (p *printer) space() {
+	if p.options.Compact {
+		p.spaced = true
+		return
+	}
+
+	p.print(" ")
+}
+
+// runTogether reports whether two tokens would be read as one without a space
+// between them, like 'return a', 'a + +b' or the end of the type arguments of
+// 'let a: A<B> = c'.
+func runTogether(last, first byte) bool {
+	if isIdentifierByte(last) && isIdentifierByte(first) {
+		return true
+	}
+
+	return last == first && (last == '+' || last == '-') || last == '>' && (first == '=' || first == '>')
+}
+
+func isIdentifierByte(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '_' || c == '$' || c == '\\' || c >= utf8.RuneSelf
+}
+
+func (p *printer) newline() {
+	if p.options.Compact {
+		return
+	}
+
+	p.out.WriteByte('\n')
+	p.out.WriteString(strings.Repeat(p.options.Indent, p.indent))
+
+	// The line of the group fit, and the following ones have their own
+	// groups.
+	p.measuring = false
+}
+
+// column returns the width of the current line.
+func (p *printer) column() int {
+	b := p.out.Bytes()
+
+	return utf8.RuneCount(b[bytes.LastIndexByte(b, '\n')+1:])
+}
+
+// group prints a list on one line if that fits in the width, or else broken
+// over several lines. Only the first line counts, as the elements may be
+// functions with bodies.
+func (p *printer) group(print func(broken bool)) {
+	if p.options.Width <= 0 || p.options.Compact || p.measuring {
+		print(false)
+		return
+	}
+
+	length, printed, starts := p.out.Len(), len(p.printed), len(p.starts)
+	starting := append([]parser.Node{}, p.starting...)
+	if p.fits(print) {
+		return
+	}
+
+	p.out.Truncate(length)
+	p.starts, p.starting = p.starts[:starts], starting
+	for _, i := range p.printed[printed:] {
+		p.done[i] = false
+		if i < p.next {
+			p.next = i
+		}
+	}
+	p.printed = p.printed[:printed]
+
+	print(true)
+}
+
+// fits prints a group on one line and reports whether it fits.
+func (p *printer) fits(print func(broken bool)) (fits bool) {
+	defer func() {
+		p.measuring = false
+
+		if r := recover(); r != nil {
+			if _, ok := r.(noFit); !ok {
+				panic(r)
+			}
+
+			fits = false
+		}
+	}()
+
+	p.measuring = true
+	print(false)
+
+	return true
+}
+
+// commaList prints a group of comma separated elements, with each element on
+// its own line when broken. An empty list stays empty.
+func (p *printer) commaList(n int, broken bool, print func(i int)) {
+	if n == 0 {
+		return
+	}
+
+	if broken {
+		p.indent++
+	}
+
+	for i := 0; i < n; i++ {
+		if broken {
+			p.newline()
+		} else if i > 0 {
+			p.space()
+		}
+
+		print(i)
+		if i < n-1 {
+			p.print(",")
+		}
+	}
+
+	if broken {
+		p.indent--
+		p.newline()
+	}
+}
+
+// semicolon ends a statement.
+func (p *printer) semicolon() {
+	if p.options.Compact {
+		p.print(";")
+		p.ended = p.out.Len()
+		return
+	}
+
+	if p.options.Semicolons != SemicolonsAsNeeded || !p.lineEnds {
+		p.print(";")
+		return
+	}
+
+	p.omitted = p.out.Len()
+}
+
+// requiredSemicolon ends a statement which the next line would continue
+// without its semicolon.
+func (p *printer) requiredSemicolon() {
+	lineEnds := p.lineEnds
+	p.lineEnds = false
+	p.semicolon()
+	p.lineEnds = lineEnds
+}
+
+// node prints a node of any kind.
+func (p *printer) node(n parser.Node) {
+	if n == nil {
+		p.errorf("cannot print a missing node")
+	}
+
+	switch t := n.Type(); {
+	case t == parser.Program:
+		p.program(n)
+	case isStatement(n):
+		p.statement(n)
+	case t == parser.VariableDeclarator:
+		p.variableDeclarator(n)
+	case t == parser.ClassBody:
+		p.classBody(n)
+	case isClassMember(n):
+		p.classMember(n)
+	case t == parser.Decorator:
+		p.decorator(n)
+	case t == parser.TSEmptyBodyFunctionExpression:
+		p.function(n)
+	case strings.HasPrefix(string(t), "JSX") && n.Not(parser.JSXElement, parser.JSXFragment):
+		p.jsx(n)
+	case strings.HasPrefix(string(t), "TS") && precedence(n) == 0:
+		p.ts(n)
+	default:
+		p.expression(n, precAssignment)
+	}
+}
+
+func (p *printer) program(n parser.Node) {
+	// Comments after the last statement may be outside of the program.
+	p.statements(list(n, "body"), len(p.options.Source)+1)
+
+	if p.options.Compact {
+		if p.ended == p.out.Len() && p.ended > 0 {
+			p.out.Truncate(p.ended - 1)
+		}
+	} else if p.out.Len() > 0 {
+		p.print("\n")
+	}
+}
+
+// statements prints a statement list. Without semicolons, statements which
+// would continue the previous one start with a semicolon.
+func (p *printer) statements(statements []parser.Node, end int) {
+	omitted := false
+	p.lines(statements, end, func(i int) {
+		start := p.out.Len()
+		p.statement(statements[i])
+
+		if omitted && p.continuesStatement(start) {
+			rest := append([]byte{}, p.out.Bytes()[start:]...)
+			p.out.Truncate(start)
+			p.out.WriteByte(';')
+			p.out.Write(rest)
+			if p.omitted >= start {
+				p.omitted++
+			}
+			for i := len(p.starts) - 1; i >= 0 && p.starts[i].offset >= start; i-- {
+				p.starts[i].offset++
+			}
+		}
+
+		omitted = p.omitted == p.out.Len()
+	})
+}
+
+// continuesStatement reports whether the statement printed from start would
+// continue a statement before it without a semicolon.
+func (p *printer) continuesStatement(start int) bool {
+	// The first token may follow inline comments.
+	first := p.out.Bytes()[start:]
+	for bytes.HasPrefix(first, []byte("/*")) {
+		first = bytes.TrimLeft(first[bytes.Index(first, []byte("*/"))+2:], " ")
+	}
+
+	switch first[0] {
+	case '(', '[', '+', '-', '<', '!', ';':
+		return true
+	}
+
+	return false
+}
+
+func isStatement(n parser.Node) bool {
+	switch n.Type() {
+	case parser.ExpressionStatement, parser.BlockStatement, parser.EmptyStatement, parser.VariableDeclaration,
+		parser.IfStatement, parser.WhileStatement, parser.ForStatement, parser.DoWhileStatement,
+		parser.FunctionDeclaration, parser.ReturnStatement, parser.ClassDeclaration, parser.ErrorNode,
+		parser.TSInterfaceDeclaration, parser.TSTypeAliasDeclaration, parser.TSEnumDeclaration:
+		return true
+	}
+
+	return false
+}
+
+func (p *printer) statement(n parser.Node) {
+	p.start(n)
+
+	switch n.Type() {
+	case parser.ExpressionStatement:
+		p.expressionStatement(n)
+	case parser.BlockStatement:
+		p.block(n)
+	case parser.EmptyStatement:
+		p.print(";")
+	case parser.VariableDeclaration:
+		p.variableDeclaration(n)
+		p.semicolon()
+	case parser.IfStatement:
+		p.ifStatement(n)
+	case parser.WhileStatement:
+		p.print("while")
+		p.space()
+		p.print("(")
+		p.expression(child(n, "test"), precAssignment)
+		p.print(")")
+		p.body(child(n, "body"))
+	case parser.DoWhileStatement:
+		p.print("do")
+		p.bodyBefore(child(n, "body"))
+		p.space()
+		p.print("while")
+		p.space()
+		p.print("(")
+		p.expression(child(n, "test"), precAssignment)
+		p.print(")")
+		p.semicolon()
+	case parser.ForStatement:
+		p.forStatement(n)
+	case parser.FunctionDeclaration:
+		p.function(n)
+	case parser.ReturnStatement:
+		p.print("return")
+		if argument := child(n, "argument"); argument != nil {
+			p.space()
+			p.expression(argument, precAssignment)
+		}
+		p.semicolon()
+	case parser.ClassDeclaration:
+		p.class(n)
+	case parser.TSInterfaceDeclaration, parser.TSTypeAliasDeclaration, parser.TSEnumDeclaration:
+		p.ts(n)
+	default:
+		p.errorf("cannot print %s", n.Type())
+	}
+}
+
+// ExpressionStatement
+// 	: Expression ';'
+// 	;
+//
+// Expressions starting with 'class' or 'function' would be declarations, and
+// strings which aren't directives would become directives, so they are
+// parenthesized.
+func (p *printer) expressionStatement(n parser.Node) {
+	expression := child(n, "expression")
+
+	_, isDirective := n["directive"]
+	first := leftmost(expression)
+	if first.Is(parser.ClassExpression, parser.FunctionExpression) ||
+		!isDirective && expression.Is(parser.Literal) && isString(expression) {
+		p.print("(")
+		p.expression(expression, precAssignment)
+		p.print(")")
+	} else {
+		p.expression(expression, precAssignment)
+	}
+
+	// A lone word starting a declaration would start one with the next
+	// line, as in 'interface\nA'.
+	if expression.Is(parser.Identifier) && declarationWords[str(expression, "name")] {
+		p.requiredSemicolon()
+	} else {
+		p.semicolon()
+	}
+}
+
+// declarationWords are the contextual keywords which start declarations.
+var declarationWords = map[string]bool{
+	"let": true, "interface": true, "type": true, "enum": true, "abstract": true,
+	"declare": true, "namespace": true, "module": true,
+}
+
+// leftmost returns the expression printed first in an expression, without
+// parentheses.
+func leftmost(n parser.Node) parser.Node {
+	var next parser.Node
+	switch n.Type() {
+	case parser.BinaryExpression, parser.LogicalExpression, parser.AssignmentExpression:
+		next = child(n, "left")
+	case parser.MemberExpression:
+		next = child(n, "object")
+	case parser.CallExpression:
+		next = child(n, "callee")
+	case parser.TSAsExpression, parser.TSSatisfiesExpression, parser.TSNonNullExpression:
+		next = child(n, "expression")
+	}
+
+	if next == nil || precedence(next) < precedence(n) {
+		return n
+	}
+
+	return leftmost(next)
+}
+
+func (p *printer) block(n parser.Node) {
+	statements := list(n, "body")
+	if len(statements) == 0 && !p.hasComments(n.End()) {
+		p.print("{}")
+		return
+	}
+
+	p.print("{")
+	p.indent++
+	p.newline()
+	p.statements(statements, n.End())
+	p.indent--
+	p.newline()
+	p.print("}")
+}
+
+// body prints the body of a loop or an if statement after its head.
+func (p *printer) body(n parser.Node) {
+	p.space()
+	p.statement(n)
+}
+
+// bodyBefore prints a body followed by more of its statement on the same
+// line, so it keeps its semicolon.
+func (p *printer) bodyBefore(n parser.Node) {
+	lineEnds := p.lineEnds
+	p.lineEnds = false
+	p.body(n)
+	p.lineEnds = lineEnds
+}
+
+// IfStatement
+// 	: 'if' '(' Expression ')' Statement
+// 	| 'if' '(' Expression ')' Statement 'else' Statement
+// 	;
+//
+// An else would belong to an if without else at the end of the consequent,
+// which is put in a block to keep it.
+func (p *printer) ifStatement(n parser.Node) {
+	p.print("if")
+	p.space()
+	p.print("(")
+	p.expression(child(n, "test"), precAssignment)
+	p.print(")")
+
+	consequent, alternate := child(n, "consequent"), child(n, "alternate")
+	if alternate == nil {
+		p.body(consequent)
+		return
+	}
+
+	if hasDanglingIf(consequent) {
+		p.space()
+		p.block(parser.NewBlockStatement(consequent.Start(), consequent.End(), consequent))
+	} else {
+		p.bodyBefore(consequent)
+	}
+
+	p.space()
+	p.print("else")
+	p.body(alternate)
+}
+
+// hasDanglingIf reports whether a statement ends with an if without else.
+func hasDanglingIf(n parser.Node) bool {
+	switch n.Type() {
+	case parser.IfStatement:
+		if alternate := child(n, "alternate"); alternate != nil {
+			return hasDanglingIf(alternate)
+		}
+
+		return true
+	case parser.WhileStatement, parser.ForStatement:
+		return hasDanglingIf(child(n, "body"))
+	}
+
+	return false
+}
+
+// ForStatement
+// 	: 'for' '(' OptForInit ';' OptExpression ';' OptExpression ')' Statement
+// 	;
+func (p *printer) forStatement(n parser.Node) {
+	p.print("for")
+	p.space()
+	p.print("(")
+	if init := child(n, "init"); init != nil {
+		if init.Is(parser.VariableDeclaration) {
+			p.variableDeclaration(init)
+		} else {
+			p.expression(init, precAssignment)
+		}
+	}
+	p.print(";")
+
+	if test := child(n, "test"); test != nil {
+		p.space()
+		p.expression(test, precAssignment)
+	}
+	p.print(";")
+
+	if update := child(n, "update"); update != nil {
+		p.space()
+		p.expression(update, precAssignment)
+	}
+	p.print(")")
+
+	p.body(child(n, "body"))
+}
+
+// VariableDeclaration
+// 	: VARIABLE_DECLARATION_KEYWORD VariableDeclaratorList
+// 	;
+//
+// When broken, the declarators after the first one are indented.
+func (p *printer) variableDeclaration(n parser.Node) {
+	p.print(str(n, "kind"))
+	p.space()
+
+	declarations := list(n, "declarations")
+	if len(declarations) == 1 {
+		p.variableDeclarator(declarations[0])
+		return
+	}
+
+	p.group(func(broken bool) {
+		if broken {
+			p.indent++
+			defer func() {
+				p.indent--
+			}()
+		}
+
+		for i, declarator := range declarations {
+			if i > 0 {
+				p.print(",")
+				if broken {
+					p.newline()
+				} else {
+					p.space()
+				}
+			}
+
+			p.variableDeclarator(declarator)
+		}
+	})
+}
+
+func (p *printer) variableDeclarator(n parser.Node) {
+	p.start(n)
+	p.binding(child(n, "id"), flag(n, "definite"))
+	if init := child(n, "init"); init != nil {
+		p.space()
+		p.print("=")
+		p.space()
+		p.expression(init, precAssignment)
+	}
+}
+
+// binding prints an identifier with its optional or definite mark and its
+// type annotation.
+func (p *printer) binding(id parser.Node, definite bool) {
+	if id.Not(parser.Identifier) {
+		p.expression(id, precAssignment)
+		return
+	}
+
+	p.inlineComments(id.Start())
+	p.start(id)
+	p.print(str(id, "name"))
+	if flag(id, "optional") {
+		p.print("?")
+	}
+	if definite {
+		p.print("!")
+	}
+	p.typeAnnotation(child(id, "typeAnnotation"))
+}
+
+// FunctionDeclaration
+// 	: 'function' Identifier OptTypeParameters '(' OptParameterList ')' OptTypeAnnotation FunctionBody
+// 	;
+func (p *printer) function(n parser.Node) {
+	p.print("function")
+	if id := child(n, "id"); id != nil {
+		p.space()
+		p.start(id)
+		p.print(str(id, "name"))
+	}
+	p.functionRest(n)
+}
+
+// functionRest prints a function from its type parameters on.
+func (p *printer) functionRest(n parser.Node) {
+	p.typeParameters(child(n, "typeParameters"))
+	p.parameters(list(n, "params"))
+	p.typeAnnotation(child(n, "returnType"))
+
+	if body := child(n, "body"); body != nil {
+		p.space()
+		p.block(body)
+	} else {
+		p.semicolon()
+	}
+}
+
+func (p *printer) parameters(params []parser.Node) {
+	p.print("(")
+	p.group(func(broken bool) {
+		p.commaList(len(params), broken, func(i int) {
+			param := params[i]
+			if param.Is(parser.TSParameterProperty) {
+				p.modifiers(param)
+				param = child(param, "parameter")
+			}
+			p.binding(param, false)
+		})
+		p.print(")")
+	})
+}
+
+// ClassDeclaration
+// 	: OptDecoratorList 'class' Identifier OptTypeParameters OptClassHeritage ClassBody
+// 	;
+func (p *printer) class(n parser.Node) {
+	p.decorators(n)
+	if flag(n, "abstract") {
+		p.print("abstract")
+		p.space()
+	}
+
+	p.print("class")
+	if id := child(n, "id"); id != nil {
+		p.space()
+		p.start(id)
+		p.print(str(id, "name"))
+	}
+	p.typeParameters(child(n, "typeParameters"))
+
+	if superClass := child(n, "superClass"); superClass != nil {
+		p.space()
+		p.print("extends")
+		p.space()
+		p.expression(superClass, precCall)
+		p.typeArguments(child(n, "superTypeArguments"))
+	}
+
+	if implements := list(n, "implements"); len(implements) > 0 {
+		p.space()
+		p.print("implements")
+		p.space()
+		p.heritage(implements)
+	}
+
+	p.space()
+	p.classBody(child(n, "body"))
+}
+
+func (p *printer) classBody(n parser.Node) {
+	members := list(n, "body")
+	if len(members) == 0 && !p.hasComments(n.End()) {
+		p.print("{}")
+		return
+	}
+
+	p.print("{")
+	p.indent++
+	p.newline()
+	p.lines(members, n.End(), func(i int) {
+		p.classMember(members[i])
+	})
+	p.indent--
+	p.newline()
+	p.print("}")
+}
+
+func (p *printer) decorators(n parser.Node) {
+	for _, decorator := range list(n, "decorators") {
+		p.decorator(decorator)
+		p.space()
+	}
+}
+
+// Decorator
+// 	: '@' DecoratorMemberExpression
+// 	| '@' DecoratorMemberExpression '(' OptArgumentList ')'
+// 	| '@' '(' Expression ')'
+// 	;
+func (p *printer) decorator(n parser.Node) {
+	p.start(n)
+	p.print("@")
+
+	expression := child(n, "expression")
+	if isDecoratorMemberExpression(expression) ||
+		expression.Is(parser.CallExpression) && isDecoratorMemberExpression(child(expression, "callee")) {
+		p.expression(expression, precCall)
+	} else {
+		p.print("(")
+		p.expression(expression, precAssignment)
+		p.print(")")
+	}
+}
+
+// isDecoratorMemberExpression reports whether an expression is an identifier
+// or a chain of property accesses, which decorators allow without
+// parentheses.
+func isDecoratorMemberExpression(n parser.Node) bool {
+	if n.Is(parser.MemberExpression) && !flag(n, "computed") {
+		return isDecoratorMemberExpression(child(n, "object"))
+	}
+
+	return n.Is(parser.Identifier)
+}
+
+func isClassMember(n parser.Node) bool {
+	return n.Is(parser.MethodDefinition, parser.PropertyDefinition, parser.AccessorProperty,
+		parser.TSAbstractMethodDefinition, parser.TSAbstractPropertyDefinition)
+}
+
+// ClassMemberDefinition
+// 	: OptDecoratorList OptClassMemberModifierList PropertyDefinition
+// 	| OptDecoratorList OptClassMemberModifierList MethodDefinition
+// 	;
+func (p *printer) classMember(n parser.Node) {
+	p.start(n)
+	p.decorators(n)
+	p.modifiers(n)
+
+	isMethod := n.Is(parser.MethodDefinition, parser.TSAbstractMethodDefinition)
+	if isMethod {
+		switch kind := fmt.Sprint(n["kind"]); kind {
+		case string(parser.GetMethod), string(parser.SetMethod):
+			p.print(kind)
+			p.space()
+		}
+	}
+
+	p.key(n)
+	if flag(n, "optional") {
+		p.print("?")
+	}
+
+	if isMethod {
+		p.functionRest(child(n, "value"))
+		return
+	}
+
+	if flag(n, "definite") {
+		p.print("!")
+	}
+	p.typeAnnotation(child(n, "typeAnnotation"))
+	if value := child(n, "value"); value != nil {
+		p.space()
+		p.print("=")
+		p.space()
+		p.expression(value, precAssignment)
+		p.semicolon()
+	} else if isModifierName(n) {
+		// A field named like a modifier would modify the next member.
+		p.requiredSemicolon()
+	} else {
+		p.semicolon()
+	}
+}
+
+// isModifierName reports whether a field is only a name which can modify a
+// class member, as in 'static;'.
+func isModifierName(n parser.Node) bool {
+	key := child(n, "key")
+	if flag(n, "computed") || key.Not(parser.Identifier) ||
+		flag(n, "optional") || flag(n, "definite") || child(n, "typeAnnotation") != nil {
+		return false
+	}
+
+	switch str(key, "name") {
+	case "static", "get", "set", "async", "accessor", "declare", "readonly",
+		"abstract", "override", "public", "private", "protected":
+		return true
+	}
+
+	return false
+}
+
+func (p *printer) key(n parser.Node) {
+	key := child(n, "key")
+	if flag(n, "computed") {
+		p.print("[")
+		p.expression(key, precAssignment)
+		p.print("]")
+	} else {
+		p.expression(key, precPrimary)
+	}
+}
+
+// modifiers prints the modifiers of class members and parameter properties.
+func (p *printer) modifiers(n parser.Node) {
+	if accessibility, ok := n["accessibility"].(string); ok && accessibility != "" {
+		p.print(accessibility)
+		p.space()
+	}
+
+	for _, modifier := range []string{"static", "declare", "abstract", "override", "readonly", "accessor"} {
+		switch {
+		case modifier == "abstract" && n.Is(parser.TSAbstractMethodDefinition, parser.TSAbstractPropertyDefinition),
+			modifier == "accessor" && n.Is(parser.AccessorProperty),
+			modifier != "abstract" && modifier != "accessor" && flag(n, modifier):
+			p.print(modifier)
+			p.space()
+		}
+	}
+}
+
+// The precedences of expressions, from the loosest to the tightest, as the
+// parser's functions from assignmentExpression to primaryExpression encode
+// them.
+const (
+	precAssignment = iota + 1
+	precLogicalOr
+	precLogicalAnd
+	precEquality
+	precRelational
+	precAdditive
+	precMultiplicative
+	precUnary
+	precCall
+	precMember
+	precPrimary
+)
+
+// precedence returns the precedence of an expression, or 0 for other nodes.
+func precedence(n parser.Node) int {
+	switch n.Type() {
+	case parser.AssignmentExpression:
+		return precAssignment
+	case parser.LogicalExpression:
+		if str(n, "operator") == "||" {
+			return precLogicalOr
+		}
+
+		return precLogicalAnd
+	case parser.BinaryExpression:
+		switch str(n, "operator") {
+		case "==", "!=", "===", "!==":
+			return precEquality
+		case "<", ">", "<=", ">=":
+			return precRelational
+		case "+", "-":
+			return precAdditive
+		}
+
+		return precMultiplicative
+	case parser.TSAsExpression, parser.TSSatisfiesExpression:
+		return precRelational
+	case parser.UnaryExpression:
+		return precUnary
+	case parser.CallExpression:
+		return precCall
+	case parser.TSNonNullExpression:
+		if precedence(child(n, "expression")) >= precMember {
+			return precMember
+		}
+
+		return precCall
+	case parser.MemberExpression:
+		return precMember
+	case parser.Literal, parser.Identifier, parser.ThisExpression, parser.SuperExpression,
+		parser.FunctionExpression, parser.ClassExpression, parser.JSXElement, parser.JSXFragment:
+		return precPrimary
+	}
+
+	return 0
+}
+
+// expression prints an expression, in parentheses when its precedence is
+// lower than the given one.
+func (p *printer) expression(n parser.Node, prec int) {
+	if n == nil {
+		p.errorf("cannot print a missing expression")
+	}
+
+	own := precedence(n)
+	if own == 0 {
+		p.errorf("cannot print %s as an expression", n.Type())
+	}
+
+	p.inlineComments(n.Start())
+
+	if own < prec {
+		p.print("(")
+		defer p.print(")")
+	}
+	p.start(n)
+
+	switch n.Type() {
+	case parser.AssignmentExpression:
+		p.expression(child(n, "left"), precCall)
+		p.space()
+		p.print(str(n, "operator"))
+		p.space()
+		p.expression(child(n, "right"), precAssignment)
+	case parser.LogicalExpression, parser.BinaryExpression:
+		left := child(n, "left")
+		if own == precRelational && left.Is(parser.TSAsExpression, parser.TSSatisfiesExpression) {
+			// 'a as T < b' reads '<' as the start of type arguments.
+			p.expression(left, own+1)
+		} else {
+			p.expression(left, own)
+		}
+		p.space()
+		p.print(str(n, "operator"))
+		p.space()
+		p.expression(child(n, "right"), own+1)
+	case parser.TSAsExpression, parser.TSSatisfiesExpression:
+		p.expression(child(n, "expression"), precRelational)
+		if n.Is(parser.TSAsExpression) {
+			p.space()
+			p.print("as")
+			p.space()
+		} else {
+			p.space()
+			p.print("satisfies")
+			p.space()
+		}
+		p.tsType(child(n, "typeAnnotation"), typePrecFunction)
+	case parser.UnaryExpression:
+		operator, argument := str(n, "operator"), child(n, "argument")
+		p.print(operator)
+		if argument.Is(parser.UnaryExpression) && (operator == "+" || operator == "-") && str(argument, "operator") == operator {
+			// '--a' and '++a' would be updates.
+			p.space()
+		}
+		p.expression(argument, precUnary)
+	case parser.CallExpression:
+		p.expression(child(n, "callee"), precCall)
+		p.typeArguments(child(n, "typeArguments"))
+		p.print("(")
+		arguments := list(n, "arguments")
+		p.group(func(broken bool) {
+			p.commaList(len(arguments), broken, func(i int) {
+				p.expression(arguments[i], precAssignment)
+			})
+			p.print(")")
+		})
+	case parser.MemberExpression:
+		p.memberExpression(n)
+	case parser.TSNonNullExpression:
+		p.expression(child(n, "expression"), precCall)
+		p.print("!")
+	case parser.Literal:
+		p.literal(n)
+	case parser.Identifier:
+		p.print(str(n, "name"))
+	case parser.ThisExpression:
+		p.print("this")
+	case parser.SuperExpression:
+		p.print("super")
+	case parser.FunctionExpression:
+		p.function(n)
+	case parser.ClassExpression:
+		p.class(n)
+	case parser.JSXElement, parser.JSXFragment:
+		p.jsx(n)
+	}
+}
+
+// MemberExpression
+// 	: MemberExpression '.' Identifier
+// 	| MemberExpression '[' Expression ']'
+// 	;
+//
+// The parser only reads members of member expressions, so calls are
+// parenthesized, as are integers, whose '.' would be a decimal point.
+func (p *printer) memberExpression(n parser.Node) {
+	object := child(n, "object")
+	if object.Is(parser.Literal) && isInteger(object) {
+		p.print("(")
+		p.literal(object)
+		p.print(")")
+	} else {
+		p.expression(object, precMember)
+	}
+
+	if flag(n, "computed") {
+		p.print("[")
+		p.expression(child(n, "property"), precAssignment)
+		p.print("]")
+	} else {
+		p.print(".")
+		p.expression(child(n, "property"), precPrimary)
+	}
+}
+
+func (p *printer) literal(n parser.Node) {
+	if raw, ok := n["raw"].(string); ok && raw != "" {
+		if quote := p.options.Quote; quote != 0 && isString(n) && !strings.ContainsAny(raw[1:len(raw)-1], `"'`) {
+			raw = string(quote) + raw[1:len(raw)-1] + string(quote)
+		}
+
+		p.print(raw)
+		return
+	}
+
+	switch value := n["value"].(type) {
+	case nil:
+		p.print("null")
+	case string:
+		p.print(Quote(value, '"'))
+	case bool:
+		p.print(strconv.FormatBool(value))
+	case int:
+		p.print(strconv.Itoa(value))
+	case float64:
+		p.print(strconv.FormatFloat(value, 'g', -1, 64))
+	default:
+		p.errorf("cannot print the literal %#v", value)
+	}
+}
+
+// Quote returns a string literal for a string, quoted with a single or a
+// double quote.
+func Quote(s string, quote byte) string {
+	res := strings.Builder{}
+	res.WriteByte(quote)
+
+	for _, c := range s {
+		switch c {
+		case rune(quote), '\\':
+			res.WriteByte('\\')
+			res.WriteRune(c)
+		case '\n':
+			res.WriteString(`\n`)
+		case '\r':
+			res.WriteString(`\r`)
+		case '\t':
+			res.WriteString(`\t`)
+		case '\u2028', '\u2029':
+			fmt.Fprintf(&res, `\u%04x`, c)
+		default:
+			if c < 0x20 {
+				fmt.Fprintf(&res, `\x%02x`, c)
+			} else {
+				res.WriteRune(c)
+			}
+		}
+	}
+
+	res.WriteByte(quote)
+
+	return res.String()
+}
+
+func isString(literal parser.Node) bool {
+	_, ok := literal["value"].(string)
+
+	return ok
+}
+
+func isInteger(literal parser.Node) bool {
+	raw, ok := literal["raw"].(string)
+	if !ok {
+		_, isInt := literal["value"].(int)
+
+		return isInt
+	}
+
+	return raw != "" && strings.Trim(raw, "0123456789") == ""
+}
+
+// child returns a child node, or nil if it is missing.
+func child(n parser.Node, key string) parser.Node {
+	c, _ := n[key].(parser.Node)
+
+	return c
+}
+
+func list(n parser.Node, key string) []parser.Node {
+	l, _ := n[key].([]parser.Node)
+
+	return l
+}
+
+func str(n parser.Node, key string) string {
+	return fmt.Sprint(n[key])
+}
+
+func flag(n parser.Node, key string) bool {
+	b, _ := n[key].(bool)
+
+	return b
+}
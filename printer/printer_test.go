@@ -0,0 +1,311 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	b "github.com/0xvesion/go-js-parser/builder"
+	"github.com/0xvesion/go-js-parser/internal/testsource"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
+)
+
+func print(t *testing.T, n parser.Node) string {
+	t.Helper()
+
+	src, err := printer.Print(n, printer.DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return src
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range testsource.Sources {
+		expected := testsource.Parse(t, test.Src, test.Options)
+		printed := print(t, expected)
+
+		actual := testsource.Parse(t, printed, test.Options)
+		if !parser.Equal(expected, actual, parser.CodeOptions) {
+			t.Errorf("Printing %s changed the tree:\n%s", test.Src, printed)
+		}
+
+		if again := print(t, actual); again != printed {
+			t.Errorf("Printing %s isn't stable.\nfirst: %s\nthen: %s", test.Src, printed, again)
+		}
+	}
+}
+
+func TestParentheses(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{`(a + b) * c;`, `(a + b) * c;`},
+		{`a + (b * c);`, `a + b * c;`},
+		{`(a - b) - c;`, `a - b - c;`},
+		{`a - (b - c);`, `a - (b - c);`},
+		{`((a)) = (b = (c));`, `a = b = c;`},
+		{`(a == b) == (c < d);`, `a == b == c < d;`},
+		{`a && (b || c);`, `a && (b || c);`},
+		{`(a && b) || c;`, `a && b || c;`},
+		{`-(-a);`, `- -a;`},
+		{`-(+a);`, `-+a;`},
+		{`!(a + b);`, `!(a + b);`},
+		{`(f(a)).b;`, `(f(a)).b;`},
+		{`(a.b)(c);`, `a.b(c);`},
+		{`((a)(b))(c);`, `a(b)(c);`},
+		{`(1).a;`, `(1).a;`},
+		{`("a").length;`, `"a".length;`},
+		{`((class {})).a;`, `(class {}.a);`},
+		{`x = (class {}).a;`, `x = class {}.a;`},
+		{`("a");`, `("a");`},
+		{`("a") + b;`, `"a" + b;`},
+		{`(a * b) / c;`, `a * b / c;`},
+		{`a * (b / c);`, `a * (b / c);`},
+		{`(a || b) || c;`, `a || b || c;`},
+		{`a || (b || c);`, `a || (b || c);`},
+		{`a < (b < c);`, `a < (b < c);`},
+		{`(a = b) + c;`, `(a = b) + c;`},
+		{`f((a = 1));`, `f(a = 1);`},
+		{`-(a * b);`, `-(a * b);`},
+		{`(-a).b;`, `(-a).b;`},
+		{`(!a)(b);`, `(!a)(b);`},
+		{`(a + b).c;`, `(a + b).c;`},
+	}
+
+	for _, test := range tests {
+		if actual := print(t, testsource.Parse(t, test.src, parser.Options{})); actual != test.expected+"\n" {
+			t.Errorf("Unexpected output for %s.\nwant: %s\ngot: %s", test.src, test.expected, actual)
+		}
+	}
+
+	for src, expected := range map[string]string{
+		`(a as T).b;`:   `(a as T).b;`,
+		`(a as T) + 1;`: `(a as T) + 1;`,
+		`a + (b as T);`: `a + (b as T);`,
+		`(a!).b;`:       `a!.b;`,
+	} {
+		if actual := print(t, testsource.Parse(t, src, parser.Options{TypeScript: true})); actual != expected+"\n" {
+			t.Errorf("Unexpected output for %s.\nwant: %s\ngot: %s", src, expected, actual)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	src := `// head
+
+/* a */ let a = 1; // tail
+
+f(/* x */ b, c);
+class A {
+  // m
+  m() {} // after m
+
+  n() { /* empty */ }
+}
+// end`
+
+	expected := `// head
+
+/* a */
+let a = 1; // tail
+
+f(/* x */ b, c);
+class A {
+  // m
+  m() {} // after m
+
+  n() {
+    /* empty */
+  }
+}
+// end
+`
+
+	options := printer.DefaultOptions
+	options.Source = src
+	if actual, _ := printer.Print(testsource.Parse(t, src, parser.Options{}), options); actual != expected {
+		t.Errorf("Unexpected comments.\nwant: %s\ngot: %s", expected, actual)
+	}
+
+	options.Compact = true
+	if actual, _ := printer.Print(testsource.Parse(t, src, parser.Options{}), options); strings.Contains(actual, "/*") {
+		t.Errorf("Expected compact code without comments, got: %s", actual)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{`short(a, b);`, "short(a, b);\n"},
+		{`f(firstArgument, secondArgument, third);`, "f(\n  firstArgument,\n  secondArgument,\n  third\n);\n"},
+		{`outer(inner(argumentNumberOne, argumentNumberTwo));`, "outer(\n  inner(\n    argumentNumberOne,\n    argumentNumberTwo\n  )\n);\n"},
+		{`function g(alpha, beta, gamma, delta) { return h(alpha, beta); }`, "function g(\n  alpha,\n  beta,\n  gamma,\n  delta\n) {\n  return h(alpha, beta);\n}\n"},
+		{`let first = 1, second = 2, third = 3;`, "let first = 1,\n  second = 2,\n  third = 3;\n"},
+	}
+
+	for _, test := range tests {
+		n := testsource.Parse(t, test.src, parser.Options{})
+		if actual, _ := printer.Print(n, printer.Options{Indent: "  ", Width: 30}); actual != test.expected {
+			t.Errorf("Unexpected breaks of %s.\nwant: %q\ngot: %q", test.src, test.expected, actual)
+		}
+		if actual, _ := printer.Print(n, printer.Options{Indent: "  "}); strings.Contains(actual, "(\n") || strings.Contains(actual, ",\n") {
+			t.Errorf("Expected no breaks without a width, got: %q", actual)
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	src := `function f(a) { if (a) { return 1; } else { while (a) a = a - 1; } let x = class A { m() {} static p = 1; }; }
+interface I { a: string; b(): void; } enum E { A, B = 2 } type T = { a: string; b: number };`
+
+	expected := `function f(a) {
+  if (a) {
+    return 1;
+  } else {
+    while (a) a = a - 1;
+  }
+  let x = class A {
+    m() {}
+    static p = 1;
+  };
+}
+interface I {
+  a: string;
+  b(): void;
+}
+enum E {
+  A,
+  B = 2
+}
+type T = { a: string; b: number };
+`
+
+	if actual := print(t, testsource.Parse(t, src, parser.Options{TypeScript: true})); actual != expected {
+		t.Errorf("Unexpected layout.\nwant: %s\ngot: %s", expected, actual)
+	}
+
+	src = "{ a; }\n"
+	actual, _ := printer.Print(testsource.Parse(t, src, parser.Options{}), printer.Options{Indent: "\t"})
+	if actual != "{\n\ta;\n}\n" {
+		t.Errorf("Expected tabs, got: %q", actual)
+	}
+
+	src = "type T = { x: null; y(): this } | keyof A.B | C[] & D[\"e\"] | [string, boolean] | (() => void) | A[];"
+	actual, _ = printer.Print(testsource.Parse(t, src, parser.Options{TypeScript: true}), printer.Options{Indent: "  ", Width: 80})
+	if strings.Contains(actual, "(\n") {
+		t.Errorf("Expected empty parameter lists on one line, got: %q", actual)
+	}
+
+	src = "a;;\n"
+	actual, _ = printer.Print(testsource.Parse(t, src, parser.Options{}), printer.Options{Indent: "  ", Semicolons: printer.SemicolonsAsNeeded})
+	if actual != "a\n;;\n" {
+		t.Errorf("Expected the empty statement to keep the semicolon of a, got: %q", actual)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	compact := printer.Options{Compact: true}
+
+	for _, test := range testsource.Sources {
+		expected := testsource.Parse(t, test.Src, test.Options)
+		printed, err := printer.Print(expected, compact)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := testsource.Parse(t, printed, test.Options)
+		if !parser.Equal(expected, actual, parser.CodeOptions) {
+			t.Errorf("Printing %s compactly changed the tree:\n%s", test.Src, printed)
+		}
+	}
+
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{"function f(a, b) { if (a) { return a + +b; } else return - -b; }", "function f(a,b){if(a){return a+ +b}else return- -b}"},
+		{"class A extends B { x = 1; m() { do x; while (y); } }\nf(\"a b\");", `class A extends B{x=1;m(){do x;while(y)}}f("a b")`},
+		{"for (;;) ; while (a) {} x = a.b[c](d) * (e - f);", "for(;;);while(a){}x=a.b[c](d)*(e-f)"},
+	}
+
+	for _, test := range tests {
+		actual, err := printer.Print(testsource.Parse(t, test.src, parser.Options{}), compact)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("Unexpected output.\nwant: %q\ngot: %q", test.expected, actual)
+		}
+	}
+}
+
+func TestOnNode(t *testing.T) {
+	src := "let a = 1; f(firstArgument, secondArgument, thirdArgument); (a || b).c;"
+
+	for _, options := range []printer.Options{
+		printer.DefaultOptions,
+		{Indent: "  ", Width: 20, Semicolons: printer.SemicolonsAsNeeded},
+		{Compact: true},
+	} {
+		names, offsets := []string{}, []int{}
+		options.OnNode = func(n parser.Node, offset int) {
+			if n.Is(parser.Identifier) {
+				names = append(names, parser.IdentifierNode(n).Name())
+				offsets = append(offsets, offset)
+			}
+		}
+
+		code, err := printer.Print(testsource.Parse(t, src, parser.Options{}), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(names) != 8 {
+			t.Errorf("Expected 8 identifiers, got %v", names)
+		}
+		for i, name := range names {
+			if !strings.HasPrefix(code[offsets[i]:], name) {
+				t.Errorf("%s is reported at %q of:\n%s", name, code[offsets[i]:], code)
+			}
+		}
+	}
+}
+
+func TestBuiltTrees(t *testing.T) {
+	tests := []struct {
+		n        parser.Node
+		expected string
+	}{
+		{b.Program(b.Call(b.Member(b.Ident("console"), "log"), b.Str("hi\n"))), "console.log(\"hi\\n\");\n"},
+		{b.If(b.Ident("a"), b.If(b.Ident("b"), b.Ident("c"), nil), b.Ident("d")), "if (a) {\n  if (b) c;\n} else d;"},
+		{b.Binary("*", b.Binary("+", b.Num(1), b.Num(2)), b.Unary("-", b.Num(-3))), "(1 + 2) * - -3"},
+		{b.Member(b.Call(b.Ident("f")), "x"), "(f()).x"},
+		{b.Expr(b.Call(b.Member(b.Func(nil, b.Return(nil)), "call"))), "(function() {\n  return;\n}.call());"},
+		{b.Method("m", []string{"a"}), "m(a) {}"},
+		{b.Let("a", nil)["declarations"].([]parser.Node)[0], "a"},
+	}
+
+	for _, test := range tests {
+		if actual := print(t, test.n); actual != test.expected {
+			t.Errorf("Unexpected output.\nwant: %q\ngot: %q", test.expected, actual)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, n := range []parser.Node{
+		parser.NewProgram(0, 0, parser.NewErrorNode(0, 0)),
+		parser.NewNode("Unknown", 0, 0),
+		parser.NewExpressionStatement(0, 0, parser.NewEmptyStatement(0, 0)),
+	} {
+		if _, err := printer.Print(n, printer.DefaultOptions); err == nil {
+			t.Errorf("Expected an error for %v", n)
+		}
+	}
+}
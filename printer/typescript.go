@@ -0,0 +1,421 @@
+package printer
+
+import "github.com/0xvesion/go-js-parser/parser"
+
+var tsKeywords = map[parser.Type]string{
+	parser.TSAnyKeyword:       "any",
+	parser.TSUnknownKeyword:   "unknown",
+	parser.TSNumberKeyword:    "number",
+	parser.TSObjectKeyword:    "object",
+	parser.TSBooleanKeyword:   "boolean",
+	parser.TSBigIntKeyword:    "bigint",
+	parser.TSStringKeyword:    "string",
+	parser.TSSymbolKeyword:    "symbol",
+	parser.TSVoidKeyword:      "void",
+	parser.TSUndefinedKeyword: "undefined",
+	parser.TSNullKeyword:      "null",
+	parser.TSNeverKeyword:     "never",
+	parser.TSThisType:         "this",
+}
+
+// The precedences of types, from the loosest to the tightest, as the
+// parser's functions from tsType to tsPrimaryType encode them.
+const (
+	typePrecFunction = iota + 1
+	typePrecUnion
+	typePrecIntersection
+	typePrecOperator
+	typePrecArray
+	typePrecPrimary
+)
+
+func typePrecedence(n parser.Node) int {
+	switch n.Type() {
+	case parser.TSFunctionType:
+		return typePrecFunction
+	case parser.TSUnionType:
+		return typePrecUnion
+	case parser.TSIntersectionType:
+		return typePrecIntersection
+	case parser.TSTypeOperator:
+		return typePrecOperator
+	case parser.TSArrayType, parser.TSIndexedAccessType:
+		return typePrecArray
+	case parser.TSTypeReference, parser.TSLiteralType, parser.TSTupleType, parser.TSTypeLiteral:
+		return typePrecPrimary
+	}
+
+	if _, ok := tsKeywords[n.Type()]; ok {
+		return typePrecPrimary
+	}
+
+	return 0
+}
+
+// ts prints TypeScript nodes which aren't expressions.
+func (p *printer) ts(n parser.Node) {
+	if typePrecedence(n) > 0 {
+		p.tsType(n, typePrecFunction)
+		return
+	}
+
+	switch n.Type() {
+	case parser.TSInterfaceDeclaration:
+		p.declare(n)
+		p.print("interface")
+		p.space()
+		p.print(str(child(n, "id"), "name"))
+		p.typeParameters(child(n, "typeParameters"))
+		if extends := list(n, "extends"); len(extends) > 0 {
+			p.space()
+			p.print("extends")
+			p.space()
+			p.heritage(extends)
+		}
+		p.space()
+		p.tsMembers(child(n, "body"), "body", true)
+	case parser.TSTypeAliasDeclaration:
+		p.declare(n)
+		p.print("type")
+		p.space()
+		p.print(str(child(n, "id"), "name"))
+		p.typeParameters(child(n, "typeParameters"))
+		p.space()
+		p.print("=")
+		p.space()
+		p.tsType(child(n, "typeAnnotation"), typePrecFunction)
+		p.semicolon()
+	case parser.TSEnumDeclaration:
+		p.declare(n)
+		if flag(n, "const") {
+			p.print("const")
+			p.space()
+		}
+		p.print("enum")
+		p.space()
+		p.print(str(child(n, "id"), "name"))
+		p.space()
+		p.tsEnumMembers(n)
+	case parser.TSTypeAnnotation:
+		p.typeAnnotation(n)
+	case parser.TSTypeParameterDeclaration:
+		p.typeParameters(n)
+	case parser.TSTypeParameterInstantiation:
+		p.typeArguments(n)
+	case parser.TSPropertySignature, parser.TSMethodSignature, parser.TSIndexSignature:
+		p.tsMember(n)
+	case parser.TSInterfaceBody:
+		p.tsMembers(n, "body", true)
+	case parser.TSInterfaceHeritage, parser.TSClassImplements:
+		p.heritage([]parser.Node{n})
+	case parser.TSQualifiedName:
+		p.entityName(n)
+	case parser.TSTypeParameter:
+		p.typeParameter(n)
+	case parser.TSEnumMember:
+		p.tsEnumMember(n)
+	case parser.TSParameterProperty:
+		p.parameters([]parser.Node{n})
+	default:
+		p.errorf("cannot print %s", n.Type())
+	}
+}
+
+func (p *printer) declare(n parser.Node) {
+	if flag(n, "declare") {
+		p.print("declare")
+		p.space()
+	}
+}
+
+// tsType prints a type, in parentheses when its precedence is lower than the
+// given one.
+func (p *printer) tsType(n parser.Node, prec int) {
+	if n == nil {
+		p.errorf("cannot print a missing type")
+	}
+
+	own := typePrecedence(n)
+	if own == 0 {
+		p.errorf("cannot print %s as a type", n.Type())
+	}
+
+	p.inlineComments(n.Start())
+
+	if own < prec {
+		p.print("(")
+		defer p.print(")")
+	}
+	p.start(n)
+
+	switch n.Type() {
+	case parser.TSFunctionType:
+		p.typeParameters(child(n, "typeParameters"))
+		p.parameters(list(n, "params"))
+		p.space()
+		p.print("=>")
+		p.space()
+		p.tsType(child(child(n, "returnType"), "typeAnnotation"), typePrecFunction)
+	case parser.TSUnionType, parser.TSIntersectionType:
+		operator := "|"
+		if n.Is(parser.TSIntersectionType) {
+			operator = "&"
+		}
+
+		types := list(n, "types")
+		if len(types) == 1 {
+			// A single type is only a union with a leading operator.
+			p.print(operator)
+			p.space()
+		}
+		for i, t := range types {
+			if i > 0 {
+				p.space()
+				p.print(operator)
+				p.space()
+			}
+			p.tsType(t, own+1)
+		}
+	case parser.TSTypeOperator:
+		p.print(str(n, "operator"))
+		p.space()
+		p.tsType(child(n, "typeAnnotation"), typePrecOperator)
+	case parser.TSArrayType:
+		p.tsType(child(n, "elementType"), typePrecArray)
+		p.print("[]")
+	case parser.TSIndexedAccessType:
+		p.tsType(child(n, "objectType"), typePrecArray)
+		p.print("[")
+		p.tsType(child(n, "indexType"), typePrecFunction)
+		p.print("]")
+	case parser.TSTypeReference:
+		p.entityName(child(n, "typeName"))
+		p.typeArguments(child(n, "typeArguments"))
+	case parser.TSLiteralType:
+		p.expression(child(n, "literal"), precUnary)
+	case parser.TSTupleType:
+		p.print("[")
+		for i, t := range list(n, "elementTypes") {
+			if i > 0 {
+				p.print(",")
+				p.space()
+			}
+			p.tsType(t, typePrecFunction)
+		}
+		p.print("]")
+	case parser.TSTypeLiteral:
+		// Comments need the lines of an interface.
+		multiline := p.hasComments(n.End())
+		p.group(func(broken bool) {
+			p.tsMembers(n, "members", broken || multiline)
+		})
+	default:
+		p.print(tsKeywords[n.Type()])
+	}
+}
+
+func (p *printer) entityName(n parser.Node) {
+	if n.Is(parser.TSQualifiedName) {
+		p.entityName(child(n, "left"))
+		p.print(".")
+		p.entityName(child(n, "right"))
+		return
+	}
+
+	p.print(str(n, "name"))
+}
+
+func (p *printer) typeAnnotation(n parser.Node) {
+	if n == nil {
+		return
+	}
+
+	p.print(":")
+	p.space()
+	p.tsType(child(n, "typeAnnotation"), typePrecFunction)
+}
+
+func (p *printer) typeParameters(n parser.Node) {
+	if n == nil {
+		return
+	}
+
+	p.print("<")
+	for i, param := range list(n, "params") {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+
+		p.typeParameter(param)
+	}
+	p.print(">")
+}
+
+func (p *printer) typeParameter(n parser.Node) {
+	for _, modifier := range []string{"const", "in", "out"} {
+		if flag(n, modifier) {
+			p.print(modifier)
+			p.space()
+		}
+	}
+
+	p.print(str(child(n, "name"), "name"))
+	if constraint := child(n, "constraint"); constraint != nil {
+		p.space()
+		p.print("extends")
+		p.space()
+		p.tsType(constraint, typePrecFunction)
+	}
+	if defaultType := child(n, "default"); defaultType != nil {
+		p.space()
+		p.print("=")
+		p.space()
+		p.tsType(defaultType, typePrecFunction)
+	}
+}
+
+func (p *printer) typeArguments(n parser.Node) {
+	if n == nil {
+		return
+	}
+
+	p.print("<")
+	for i, param := range list(n, "params") {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+		p.tsType(param, typePrecFunction)
+	}
+	p.print(">")
+}
+
+// heritage prints the list of an 'extends' or 'implements' clause.
+func (p *printer) heritage(list []parser.Node) {
+	for i, h := range list {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+		p.expression(child(h, "expression"), precMember)
+		p.typeArguments(child(h, "typeArguments"))
+	}
+}
+
+// tsMembers prints the members of an interface or a type literal, one per
+// line, or all on one line.
+func (p *printer) tsMembers(n parser.Node, key string, multiline bool) {
+	members := list(n, key)
+	if len(members) == 0 && !(multiline && p.hasComments(n.End())) {
+		p.print("{}")
+		return
+	}
+
+	if !multiline || p.options.Compact {
+		p.print("{")
+		for i, member := range members {
+			p.space()
+			p.tsMember(member)
+			if i < len(members)-1 {
+				p.print(";")
+			}
+		}
+		p.space()
+		p.print("}")
+		return
+	}
+
+	p.print("{")
+	p.indent++
+	p.newline()
+	p.lines(members, n.End(), func(i int) {
+		p.tsMember(members[i])
+
+		// An index signature would continue the type of the member before.
+		if p.options.Semicolons != SemicolonsAsNeeded || i < len(members)-1 && members[i+1].Is(parser.TSIndexSignature) {
+			p.print(";")
+		}
+	})
+	p.indent--
+	p.newline()
+	p.print("}")
+}
+
+func (p *printer) tsMember(n parser.Node) {
+	p.start(n)
+	if flag(n, "static") {
+		p.print("static")
+		p.space()
+	}
+	if flag(n, "readonly") {
+		p.print("readonly")
+		p.space()
+	}
+
+	switch n.Type() {
+	case parser.TSIndexSignature:
+		p.print("[")
+		for i, parameter := range list(n, "parameters") {
+			if i > 0 {
+				p.print(",")
+				p.space()
+			}
+			p.binding(parameter, false)
+		}
+		p.print("]")
+		p.typeAnnotation(child(n, "typeAnnotation"))
+	case parser.TSMethodSignature:
+		switch kind := str(n, "kind"); kind {
+		case string(parser.GetMethod), string(parser.SetMethod):
+			p.print(kind)
+			p.space()
+		}
+		p.key(n)
+		if flag(n, "optional") {
+			p.print("?")
+		}
+		p.typeParameters(child(n, "typeParameters"))
+		p.parameters(list(n, "params"))
+		p.typeAnnotation(child(n, "returnType"))
+	case parser.TSPropertySignature:
+		p.key(n)
+		if flag(n, "optional") {
+			p.print("?")
+		}
+		p.typeAnnotation(child(n, "typeAnnotation"))
+	default:
+		p.errorf("cannot print %s as a type member", n.Type())
+	}
+}
+
+func (p *printer) tsEnumMembers(n parser.Node) {
+	members := list(n, "members")
+	if len(members) == 0 && !p.hasComments(n.End()) {
+		p.print("{}")
+		return
+	}
+
+	p.print("{")
+	p.indent++
+	p.newline()
+	p.lines(members, n.End(), func(i int) {
+		p.tsEnumMember(members[i])
+		if i < len(members)-1 {
+			p.print(",")
+		}
+	})
+	p.indent--
+	p.newline()
+	p.print("}")
+}
+
+func (p *printer) tsEnumMember(n parser.Node) {
+	p.expression(child(n, "id"), precPrimary)
+	if initializer := child(n, "initializer"); initializer != nil {
+		p.space()
+		p.print("=")
+		p.space()
+		p.expression(initializer, precAssignment)
+	}
+}
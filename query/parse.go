@@ -0,0 +1,410 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SelectorError is returned by Compile for invalid selectors.
+type SelectorError struct {
+	Selector string
+	Offset   int
+	Message  string
+}
+
+func (e *SelectorError) Error() string {
+	return fmt.Sprintf("invalid selector %q at %d: %s", e.Selector, e.Offset, e.Message)
+}
+
+// selectorParser is a recursive descent parser for selectors. Like the
+// parser of the parser package, it panics with a *SelectorError which
+// Compile recovers.
+type selectorParser struct {
+	src    string
+	cursor int
+}
+
+// Selectors
+// 	: Selector
+// 	| Selectors ',' Selector
+// 	;
+func (p *selectorParser) selectors(relative bool) matcher {
+	list := anyOf{p.selector(relative)}
+
+	for p.skipSpace(); p.peek() == ','; p.skipSpace() {
+		p.cursor++
+		list = append(list, p.selector(relative))
+	}
+
+	if len(list) == 1 {
+		return list[0]
+	}
+
+	return list
+}
+
+// Selector
+// 	: Compound
+// 	| Selector Combinator Compound
+// 	;
+//
+// Combinator
+// 	: ' '
+// 	| '>'
+// 	| '~'
+// 	| '+'
+// 	;
+//
+// Relative selectors, inside ':has()', may start with a combinator and are
+// relative to the subject of ':has()'.
+func (p *selectorParser) selector(relative bool) matcher {
+	p.skipSpace()
+	if !relative {
+		return p.selectorAfter(p.compound())
+	}
+
+	op := p.combinator()
+	if op == 0 {
+		op = ' '
+	}
+
+	return p.selectorAfter(combinator{op, scope{}, p.compound()})
+}
+
+func (p *selectorParser) selectorAfter(m matcher) matcher {
+	for {
+		start := p.cursor
+		op := p.combinator()
+		if op == 0 || p.atEnd() || strings.ContainsRune(",)", rune(p.peek())) {
+			p.cursor = start
+
+			return m
+		}
+
+		m = combinator{op, m, p.compound()}
+	}
+}
+
+// combinator reads a combinator, returning ' ' for descendants and 0 when
+// there is none.
+func (p *selectorParser) combinator() byte {
+	hasSpace := p.skipSpace()
+
+	switch c := p.peek(); c {
+	case '>', '~', '+':
+		p.cursor++
+		p.skipSpace()
+
+		return c
+	}
+
+	if hasSpace {
+		return ' '
+	}
+
+	return 0
+}
+
+// Compound
+// 	: Simple
+// 	| Compound Simple
+// 	;
+//
+// Simple
+// 	: IDENTIFIER
+// 	| '*'
+// 	| Attribute
+// 	| Pseudo
+// 	;
+func (p *selectorParser) compound() matcher {
+	all := allOf{}
+
+	for !p.atEnd() {
+		switch c := p.peek(); {
+		case c == '*':
+			p.cursor++
+			all = append(all, wildcard{})
+		case c == '[':
+			all = append(all, p.attribute())
+		case c == ':':
+			all = append(all, p.pseudo())
+		case isIdentifierChar(c):
+			all = append(all, nodeType(p.identifier()))
+		default:
+			if len(all) == 0 {
+				p.error("want a selector")
+			}
+
+			return all
+		}
+	}
+
+	if len(all) == 0 {
+		p.error("want a selector")
+	}
+
+	return all
+}
+
+// Attribute
+// 	: '[' Path ']'
+// 	| '[' Path Operator Value ']'
+// 	;
+//
+// Path
+// 	: IDENTIFIER
+// 	| Path '.' IDENTIFIER
+// 	;
+//
+// Operator
+// 	: '=' | '!=' | '<' | '<=' | '>' | '>='
+// 	;
+func (p *selectorParser) attribute() matcher {
+	p.expect("[")
+	p.skipSpace()
+
+	a := attribute{path: strings.Split(p.path(), ".")}
+
+	p.skipSpace()
+	for _, op := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.src[p.cursor:], op) {
+			p.cursor += len(op)
+			p.skipSpace()
+
+			a.op = op
+			a.value = p.value(op)
+			p.skipSpace()
+
+			break
+		}
+	}
+
+	p.expect("]")
+
+	return a
+}
+
+func (p *selectorParser) path() string {
+	start := p.cursor
+	for !p.atEnd() && (isIdentifierChar(p.peek()) || p.peek() == '.') {
+		p.cursor++
+	}
+
+	path := p.src[start:p.cursor]
+	if path == "" || strings.HasPrefix(path, ".") || strings.HasSuffix(path, ".") || strings.Contains(path, "..") {
+		p.errorAt(start, "want an attribute name")
+	}
+
+	return path
+}
+
+// Value
+// 	: STRING
+// 	| NUMBER
+// 	| REGEX
+// 	| IDENTIFIER
+// 	;
+func (p *selectorParser) value(op string) value {
+	start := p.cursor
+
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return value{kind: stringValue, s: p.string()}
+	case c == '/':
+		if op != "=" && op != "!=" {
+			p.error("regular expressions can only be compared with = and !=")
+		}
+
+		return value{kind: regexValue, re: p.regex()}
+	case c == '-' || c >= '0' && c <= '9':
+		p.cursor++
+		for !p.atEnd() && (p.peek() >= '0' && p.peek() <= '9' || p.peek() == '.') {
+			p.cursor++
+		}
+
+		n, err := strconv.ParseFloat(p.src[start:p.cursor], 64)
+		if err != nil {
+			p.errorAt(start, "invalid number")
+		}
+
+		return value{kind: numberValue, n: n}
+	case isIdentifierChar(c):
+		name := p.identifier()
+		if name == "null" || name == "undefined" {
+			return value{kind: nullValue}
+		}
+
+		return value{kind: stringValue, s: name}
+	}
+
+	p.error("want a value")
+
+	return value{}
+}
+
+func (p *selectorParser) string() string {
+	quote := p.peek()
+	p.cursor++
+
+	res := strings.Builder{}
+	for !p.atEnd() && p.peek() != quote {
+		if p.peek() == '\\' && p.cursor+1 < len(p.src) {
+			p.cursor++
+		}
+
+		res.WriteByte(p.peek())
+		p.cursor++
+	}
+
+	p.expect(string(quote))
+
+	return res.String()
+}
+
+func (p *selectorParser) regex() *regexp.Regexp {
+	start := p.cursor
+	p.cursor++
+
+	pattern := strings.Builder{}
+	for !p.atEnd() && p.peek() != '/' {
+		if p.peek() == '\\' && p.cursor+1 < len(p.src) && p.src[p.cursor+1] == '/' {
+			p.cursor++
+		}
+
+		pattern.WriteByte(p.peek())
+		p.cursor++
+	}
+	p.expect("/")
+
+	flags := ""
+	for !p.atEnd() && strings.ContainsRune("imsu", rune(p.peek())) {
+		if p.peek() != 'u' {
+			flags += string(p.peek())
+		}
+		p.cursor++
+	}
+
+	expr := pattern.String()
+	if flags != "" {
+		expr = "(?" + flags + ")" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		p.errorAt(start, err.Error())
+	}
+
+	return re
+}
+
+// Pseudo
+// 	: ':' IDENTIFIER
+// 	| ':' IDENTIFIER '(' Selectors ')'
+// 	| ':' IDENTIFIER '(' NUMBER ')'
+// 	;
+func (p *selectorParser) pseudo() matcher {
+	p.expect(":")
+	start := p.cursor
+	name := p.identifier()
+
+	switch name {
+	case "not", "matches", "is", "has":
+		p.expect("(")
+		inner := p.selectors(name == "has")
+		p.skipSpace()
+		p.expect(")")
+
+		switch name {
+		case "not":
+			return not{inner}
+		case "has":
+			return has{inner}
+		}
+
+		return inner
+	case "nth-child", "nth-last-child":
+		p.expect("(")
+		p.skipSpace()
+
+		digits := p.cursor
+		for !p.atEnd() && p.peek() >= '0' && p.peek() <= '9' {
+			p.cursor++
+		}
+		n, err := strconv.Atoi(p.src[digits:p.cursor])
+		if err != nil || n < 1 {
+			p.errorAt(digits, "want a positive index")
+		}
+
+		p.skipSpace()
+		p.expect(")")
+
+		return nthChild{n, name == "nth-last-child"}
+	case "first-child":
+		return nthChild{1, false}
+	case "last-child":
+		return nthChild{1, true}
+	case "statement", "expression", "declaration", "function", "pattern":
+		return class(name)
+	}
+
+	p.errorAt(start, fmt.Sprintf("unknown pseudo-class ':%s'", name))
+
+	return nil
+}
+
+func (p *selectorParser) identifier() string {
+	start := p.cursor
+	for !p.atEnd() && isIdentifierChar(p.peek()) {
+		p.cursor++
+	}
+
+	if start == p.cursor {
+		p.error("want a name")
+	}
+
+	return p.src[start:p.cursor]
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || c == '-' || c < 0x80 && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)))
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.cursor
+	for !p.atEnd() && unicode.IsSpace(rune(p.peek())) {
+		p.cursor++
+	}
+
+	return p.cursor > start
+}
+
+func (p *selectorParser) expect(s string) {
+	if !strings.HasPrefix(p.src[p.cursor:], s) {
+		p.error(fmt.Sprintf("want '%s'", s))
+	}
+
+	p.cursor += len(s)
+}
+
+func (p *selectorParser) peek() byte {
+	if p.atEnd() {
+		return 0
+	}
+
+	return p.src[p.cursor]
+}
+
+func (p *selectorParser) atEnd() bool {
+	return p.cursor >= len(p.src)
+}
+
+func (p *selectorParser) error(message string) {
+	p.errorAt(p.cursor, message)
+}
+
+func (p *selectorParser) errorAt(offset int, message string) {
+	panic(&SelectorError{Selector: p.src, Offset: offset, Message: message})
+}
@@ -0,0 +1,411 @@
+// Package query finds nodes with CSS-like selectors, as ESQuery does:
+//
+//	s := query.MustCompile(`CallExpression[callee.property.name="then"] > FunctionExpression`)
+//	for _, n := range s.Match(program) {
+//		...
+//	}
+//
+// Selectors combine node types, '*', attributes such as [name="a"],
+// [value>1] or [name=/^on/i] on the keys of the JSON output, the descendant,
+// '>', '~' and '+' combinators, ',' and the pseudo-classes :has(), :not(),
+// :matches(), :is(), :nth-child(), :nth-last-child(), :first-child,
+// :last-child, :statement, :expression, :declaration, :function and
+// :pattern.
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+// Selector is a compiled selector, which can match any number of trees.
+type Selector struct {
+	src string
+	m   matcher
+}
+
+// Compile parses a selector.
+func Compile(selector string) (s *Selector, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			selectorErr, ok := r.(*SelectorError)
+			if !ok {
+				panic(r)
+			}
+
+			s, err = nil, selectorErr
+		}
+	}()
+
+	p := &selectorParser{src: selector}
+	m := p.selectors(false)
+	if p.skipSpace(); !p.atEnd() {
+		p.error(fmt.Sprintf("unexpected '%c'", p.peek()))
+	}
+
+	return &Selector{selector, m}, nil
+}
+
+// MustCompile is like Compile but panics for invalid selectors. It is meant
+// for selectors known when writing the program.
+func MustCompile(selector string) *Selector {
+	s, err := Compile(selector)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Query returns the nodes of root matching a selector.
+func Query(root parser.Node, selector string) ([]parser.Node, error) {
+	s, err := Compile(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Match(root), nil
+}
+
+func (s *Selector) String() string {
+	return s.src
+}
+
+// Match returns the nodes of root matching the selector, in source order.
+func (s *Selector) Match(root parser.Node) []parser.Node {
+	matches := []parser.Node{}
+	visit(root, nil, func(n parser.Node, ancestors []parser.Node) {
+		if s.m.match(n, ancestors, nil) {
+			matches = append(matches, n)
+		}
+	})
+
+	return matches
+}
+
+// Matches reports whether a node matches the selector. The ancestors go
+// from the root to the parent of the node.
+func (s *Selector) Matches(n parser.Node, ancestors []parser.Node) bool {
+	return s.m.match(n, ancestors, nil)
+}
+
+// visit calls f for n and every node below it, with its ancestors.
+func visit(n parser.Node, ancestors []parser.Node, f func(n parser.Node, ancestors []parser.Node)) {
+	walk.Walk(n, walk.Callbacks{
+		OnEnter: func(n parser.Node, _ parser.Node) walk.Action {
+			f(n, ancestors)
+			ancestors = append(ancestors, n)
+
+			return walk.Continue
+		},
+		OnLeave: func(n parser.Node, _ parser.Node) walk.Action {
+			ancestors = ancestors[:len(ancestors)-1]
+
+			return walk.Continue
+		},
+	})
+}
+
+// matcher matches a node given its ancestors. The subject is the node of the
+// innermost ':has()', to which its selectors are relative.
+type matcher interface {
+	match(n parser.Node, ancestors []parser.Node, subject parser.Node) bool
+}
+
+type wildcard struct{}
+
+func (wildcard) match(parser.Node, []parser.Node, parser.Node) bool {
+	return true
+}
+
+type nodeType string
+
+func (t nodeType) match(n parser.Node, _ []parser.Node, _ parser.Node) bool {
+	return string(n.Type()) == string(t)
+}
+
+type allOf []matcher
+
+func (all allOf) match(n parser.Node, ancestors []parser.Node, subject parser.Node) bool {
+	for _, m := range all {
+		if !m.match(n, ancestors, subject) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type anyOf []matcher
+
+func (list anyOf) match(n parser.Node, ancestors []parser.Node, subject parser.Node) bool {
+	for _, m := range list {
+		if m.match(n, ancestors, subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type not struct {
+	inner matcher
+}
+
+func (m not) match(n parser.Node, ancestors []parser.Node, subject parser.Node) bool {
+	return !m.inner.match(n, ancestors, subject)
+}
+
+// has matches nodes with a descendant matching a relative selector.
+type has struct {
+	inner matcher
+}
+
+func (h has) match(n parser.Node, ancestors []parser.Node, _ parser.Node) bool {
+	found := false
+	inner := append(append([]parser.Node{}, ancestors...), n)
+
+	for _, child := range walk.Children(n) {
+		visit(child, inner, func(d parser.Node, ancestors []parser.Node) {
+			found = found || h.inner.match(d, ancestors, n)
+		})
+	}
+
+	return found
+}
+
+// scope matches the subject of ':has()'.
+type scope struct{}
+
+func (scope) match(n parser.Node, _ []parser.Node, subject parser.Node) bool {
+	return subject != nil && same(n, subject)
+}
+
+type combinator struct {
+	op    byte
+	left  matcher
+	right matcher
+}
+
+func (c combinator) match(n parser.Node, ancestors []parser.Node, subject parser.Node) bool {
+	if !c.right.match(n, ancestors, subject) || len(ancestors) == 0 {
+		return false
+	}
+
+	parent, grandparents := ancestors[len(ancestors)-1], ancestors[:len(ancestors)-1]
+
+	switch c.op {
+	case '>':
+		return c.left.match(parent, grandparents, subject)
+	case ' ':
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			if c.left.match(ancestors[i], ancestors[:i], subject) {
+				return true
+			}
+		}
+	case '~', '+':
+		siblings, index := siblings(n, parent)
+		for i := index - 1; i >= 0; i-- {
+			if c.left.match(siblings[i], ancestors, subject) {
+				return true
+			}
+			if c.op == '+' {
+				break
+			}
+		}
+	}
+
+	return false
+}
+
+// nthChild matches the nth node of a list, counted from 1.
+type nthChild struct {
+	n       int
+	fromEnd bool
+}
+
+func (c nthChild) match(n parser.Node, ancestors []parser.Node, _ parser.Node) bool {
+	if len(ancestors) == 0 {
+		return false
+	}
+
+	siblings, index := siblings(n, ancestors[len(ancestors)-1])
+	if index < 0 {
+		return false
+	}
+	if c.fromEnd {
+		return len(siblings)-index == c.n
+	}
+
+	return index+1 == c.n
+}
+
+// siblings returns the list holding n in its parent, and the index of n.
+func siblings(n parser.Node, parent parser.Node) ([]parser.Node, int) {
+	for _, key := range walk.KeysOf(parent) {
+		list, ok := parent[key].([]parser.Node)
+		if !ok {
+			continue
+		}
+
+		for i, child := range list {
+			if child != nil && same(child, n) {
+				return list, i
+			}
+		}
+	}
+
+	return nil, -1
+}
+
+// same reports whether two nodes are the same map.
+func same(a parser.Node, b parser.Node) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// class matches kinds of nodes, by the suffix of their type as ESQuery does.
+type class string
+
+func (c class) match(n parser.Node, _ []parser.Node, _ parser.Node) bool {
+	t := string(n.Type())
+
+	switch c {
+	case "statement":
+		return strings.HasSuffix(t, "Statement") || strings.HasSuffix(t, "Declaration")
+	case "expression":
+		return strings.HasSuffix(t, "Expression") || strings.HasSuffix(t, "Literal") ||
+			n.Is(parser.Identifier, parser.SuperExpression)
+	case "declaration":
+		return strings.HasSuffix(t, "Declaration")
+	case "function":
+		return n.Is(parser.FunctionDeclaration, parser.FunctionExpression)
+	case "pattern":
+		return strings.HasSuffix(t, "Pattern") || class("expression").match(n, nil, nil)
+	}
+
+	return false
+}
+
+type valueKind int
+
+const (
+	stringValue valueKind = iota
+	numberValue
+	regexValue
+	nullValue
+)
+
+type value struct {
+	kind valueKind
+	s    string
+	n    float64
+	re   *regexp.Regexp
+}
+
+type attribute struct {
+	path  []string
+	op    string
+	value value
+}
+
+func (a attribute) match(n parser.Node, _ []parser.Node, _ parser.Node) bool {
+	v, ok := lookup(n, a.path)
+
+	switch a.op {
+	case "":
+		return ok && !isNull(v)
+	case "=":
+		return a.equals(v, ok)
+	case "!=":
+		return !a.equals(v, ok)
+	}
+
+	f, isNumber := number(v)
+	if !ok || !isNumber || a.value.kind != numberValue {
+		return false
+	}
+
+	switch a.op {
+	case "<":
+		return f < a.value.n
+	case "<=":
+		return f <= a.value.n
+	case ">":
+		return f > a.value.n
+	case ">=":
+		return f >= a.value.n
+	}
+
+	return false
+}
+
+func (a attribute) equals(v interface{}, ok bool) bool {
+	if a.value.kind == nullValue {
+		return !ok || isNull(v)
+	}
+	if !ok || isNull(v) {
+		return false
+	}
+
+	switch a.value.kind {
+	case numberValue:
+		f, isNumber := number(v)
+		return isNumber && f == a.value.n
+	case regexValue:
+		return a.value.re.MatchString(fmt.Sprint(v))
+	}
+
+	return fmt.Sprint(v) == a.value.s
+}
+
+// lookup follows a path of keys from a node. Lists are indexed by number
+// and have a length.
+func lookup(v interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return v, true
+	}
+
+	switch object := v.(type) {
+	case parser.Node:
+		if v, ok := object[path[0]]; ok {
+			return lookup(v, path[1:])
+		}
+	case map[string]interface{}:
+		if v, ok := object[path[0]]; ok {
+			return lookup(v, path[1:])
+		}
+	case []parser.Node:
+		if path[0] == "length" {
+			return lookup(len(object), path[1:])
+		}
+		if i, err := strconv.Atoi(path[0]); err == nil && i >= 0 && i < len(object) {
+			return lookup(object[i], path[1:])
+		}
+	}
+
+	return nil, false
+}
+
+func isNull(v interface{}) bool {
+	n, ok := v.(parser.Node)
+
+	return v == nil || ok && n == nil
+}
+
+func number(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+
+	return 0, false
+}
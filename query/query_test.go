@@ -0,0 +1,90 @@
+package query_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/query"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+const src = `p.then(class { m() { return 1; } });
+p.catch(x);
+let a = 1, b = 2;
+f(a, b, 3);
+class A { m() { this.x = 10; } }`
+
+// describe shows a node by its type and source.
+func describe(n parser.Node) string {
+	return fmt.Sprintf("%s %s", n.Type(), src[n.Start():n.End()])
+}
+
+func TestQuery(t *testing.T) {
+	program, err := parser.New(tokenizer.New(src)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{`CallExpression[callee.property.name="then"] > ClassExpression`, []string{"ClassExpression class { m() { return 1; } }"}},
+		{`VariableDeclarator`, []string{"VariableDeclarator a = 1", "VariableDeclarator b = 2"}},
+		{`VariableDeclarator[init.value>1]`, []string{"VariableDeclarator b = 2"}},
+		{`Literal[value<=2][value>=2]`, []string{"Literal 2"}},
+		{`Literal[value=3]`, []string{"Literal 3"}},
+		{`Literal[raw='10']`, []string{"Literal 10"}},
+		{`Identifier[name=/^(A|X)$/i]`, []string{"Identifier x", "Identifier a", "Identifier a", "Identifier A", "Identifier x"}},
+		{`Identifier[name=/^(A|X)$/]`, []string{"Identifier A"}},
+		{`CallExpression[callee.name!="f"] > Identifier`, []string{"Identifier x"}},
+		{`VariableDeclaration Identifier`, []string{"Identifier a", "Identifier b"}},
+		{`CallExpression > Identifier:first-child`, []string{"Identifier x", "Identifier a"}},
+		{`CallExpression > :nth-child(2)`, []string{"Identifier b"}},
+		{`CallExpression > :nth-last-child(1)`, []string{"ClassExpression class { m() { return 1; } }", "Identifier x", "Literal 3"}},
+		{`Identifier ~ Literal`, []string{"Literal 3"}},
+		{`Identifier + Identifier`, []string{"Identifier b"}},
+		{`ExpressionStatement:has(> CallExpression[callee.name="f"])`, []string{"ExpressionStatement f(a, b, 3);"}},
+		{`ClassDeclaration:has(ThisExpression) > Identifier`, []string{"Identifier A"}},
+		{`:statement:not(ExpressionStatement, ClassDeclaration):not(:has(Literal[value=10]))`, []string{"BlockStatement { return 1; }", "ReturnStatement return 1;", "VariableDeclaration let a = 1, b = 2;"}},
+		{`:matches(ReturnStatement, ThisExpression)`, []string{"ReturnStatement return 1;", "ThisExpression this"}},
+		{`:function`, []string{"FunctionExpression () { return 1; }", "FunctionExpression () { this.x = 10; }"}},
+		{`FunctionExpression[id=null][params.length=0] > BlockStatement`, []string{"BlockStatement { return 1; }", "BlockStatement { this.x = 10; }"}},
+		{`FunctionExpression[params.length>0]`, []string{}},
+		{`FunctionExpression[id=null] > BlockStatement:has(ReturnStatement)`, []string{"BlockStatement { return 1; }"}},
+		{`MethodDefinition[kind="method"][key.name=m]`, []string{"MethodDefinition m() { return 1; }", "MethodDefinition m() { this.x = 10; }"}},
+		{`CallExpression[arguments.2]`, []string{"CallExpression f(a, b, 3)"}},
+		{`Program > * > CallExpression[optional=false] > MemberExpression *`, []string{"Identifier p", "Identifier then", "Identifier p", "Identifier catch"}},
+	}
+
+	for _, test := range tests {
+		s, err := query.Compile(test.selector)
+		if err != nil {
+			t.Errorf("%s: %v", test.selector, err)
+			continue
+		}
+
+		actual := []string{}
+		for _, n := range s.Match(program) {
+			actual = append(actual, describe(n))
+		}
+
+		if strings.Join(actual, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("Unexpected matches of %s.\nwant: %q\ngot: %q", test.selector, test.expected, actual)
+		}
+	}
+}
+
+func TestInvalidSelectors(t *testing.T) {
+	for _, selector := range []string{``, `A >`, `[name`, `[name=]`, `[=a]`, `A:unknown`, `:nth-child(0)`, `[name>/a/]`, `[name=/(/]`, `A)`, `:not(A`} {
+		_, err := query.Compile(selector)
+
+		var selectorErr *query.SelectorError
+		if !errors.As(err, &selectorErr) {
+			t.Errorf("Expected a SelectorError for %q, got: %v", selector, err)
+		}
+	}
+}
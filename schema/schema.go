@@ -0,0 +1,299 @@
+// Package schema checks that trees are well-formed ESTree. The checks are
+// driven by estree.json, a machine-readable description of every node type:
+// the keys it has, the types of nodes allowed as its children and the legal
+// values of strings such as operators.
+//
+//	for _, err := range schema.Validate(program) {
+//		fmt.Println(err)
+//	}
+//
+// Validate also checks that the ranges of children are within the ranges of
+// their parents and don't overlap.
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+//go:embed estree.json
+var estree []byte
+
+// Field describes a key of a node.
+type Field struct {
+	// Type is "node", "list", "string", "boolean" or "value", the latter
+	// being the null, boolean, number or string value of a Literal.
+	Type string `json:"type"`
+	// Nodes are the node types, or the groups of node types, allowed for
+	// the children of "node" and "list" fields.
+	Nodes []string `json:"nodes"`
+	// Null allows a "node" field to be null.
+	Null bool `json:"null"`
+	// Enum lists the legal values of a "string" field, if they are limited.
+	Enum []string `json:"enum"`
+	// Optional allows the key to be missing.
+	Optional bool `json:"optional"`
+}
+
+var (
+	// Groups are named sets of node types, such as "Expression" or
+	// "Statement", which fields refer to.
+	Groups map[string][]parser.Type
+	// Nodes are the fields of every node type. The type, start, end, loc and
+	// range keys are common to all nodes and not listed.
+	Nodes map[parser.Type]map[string]Field
+)
+
+func init() {
+	var s struct {
+		Groups map[string][]parser.Type         `json:"groups"`
+		Nodes  map[parser.Type]map[string]Field `json:"nodes"`
+	}
+	if err := json.Unmarshal(estree, &s); err != nil {
+		panic(fmt.Errorf("invalid estree.json: %w", err))
+	}
+
+	Groups, Nodes = s.Groups, s.Nodes
+}
+
+// Error is an error found by Validate.
+type Error struct {
+	// Path leads from the root to the invalid node or value, such as
+	// "Program.body[0].expression.operator".
+	Path string
+	// Node is the invalid node, or the node holding the invalid value.
+	Node    parser.Node
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate checks a tree against the schema and returns an *Error for every
+// problem found, or nil for valid trees.
+func Validate(n parser.Node) []error {
+	v := &validator{}
+	if n == nil {
+		v.errorf("", nil, "want a node, got null")
+	} else if t, ok := n["type"].(parser.Type); ok {
+		v.node(string(t), n)
+	} else {
+		v.node("", n)
+	}
+
+	return v.errors
+}
+
+type validator struct {
+	errors []error
+}
+
+func (v *validator) errorf(path string, n parser.Node, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{Path: path, Node: n, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) node(path string, n parser.Node) {
+	t, ok := n["type"].(parser.Type)
+	if !ok {
+		v.errorf(path, n, "want a node type, got %#v", n["type"])
+		return
+	}
+
+	fields, ok := Nodes[t]
+	if !ok {
+		v.errorf(path, n, "unknown node type %s", t)
+		return
+	}
+
+	start, hasStart := n["start"].(int)
+	end, hasEnd := n["end"].(int)
+	if !hasStart || !hasEnd {
+		v.errorf(path, n, "want int start and end, got %#v and %#v", n["start"], n["end"])
+	} else if start > end {
+		v.errorf(path, n, "start %d is after end %d", start, end)
+	}
+
+	for _, key := range sortedKeys(n) {
+		switch key {
+		case "type", "start", "end", "loc", "range":
+			continue
+		}
+
+		if _, ok := fields[key]; !ok {
+			v.errorf(path+"."+key, n, "unknown key of %s", t)
+		}
+	}
+
+	for _, key := range sortedFields(fields) {
+		f := fields[key]
+		value, ok := n[key]
+		if !ok {
+			if !f.Optional {
+				v.errorf(path+"."+key, n, "missing key of %s", t)
+			}
+			continue
+		}
+
+		v.field(path+"."+key, n, f, value)
+	}
+
+	if hasStart && hasEnd && start <= end {
+		v.ranges(path, n, start, end)
+	}
+}
+
+func (v *validator) field(path string, parent parser.Node, f Field, value interface{}) {
+	switch f.Type {
+	case "node":
+		if isNull(value) {
+			if !f.Null {
+				v.errorf(path, parent, "want %s, got null", strings.Join(f.Nodes, " or "))
+			}
+			return
+		}
+
+		v.child(path, parent, f, value)
+	case "list":
+		list, ok := value.([]parser.Node)
+		if !ok {
+			v.errorf(path, parent, "want a list of %s, got %T", strings.Join(f.Nodes, " or "), value)
+			return
+		}
+
+		for i, child := range list {
+			v.child(fmt.Sprintf("%s[%d]", path, i), parent, f, child)
+		}
+	case "string":
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() || rv.Kind() != reflect.String {
+			v.errorf(path, parent, "want a string, got %#v", value)
+			return
+		}
+
+		if len(f.Enum) > 0 && !contains(f.Enum, rv.String()) {
+			v.errorf(path, parent, "want one of %s, got %q", strings.Join(f.Enum, " "), rv.String())
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.errorf(path, parent, "want a boolean, got %#v", value)
+		}
+	case "value":
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Invalid, reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		default:
+			v.errorf(path, parent, "want null, a boolean, a number or a string, got %#v", value)
+		}
+	}
+}
+
+func (v *validator) child(path string, parent parser.Node, f Field, value interface{}) {
+	child, ok := value.(parser.Node)
+	if !ok || child == nil {
+		v.errorf(path, parent, "want %s, got %#v", strings.Join(f.Nodes, " or "), value)
+		return
+	}
+
+	if t, ok := child["type"].(parser.Type); ok && Nodes[t] != nil && !allows(f.Nodes, t) {
+		v.errorf(path, child, "want %s, got %s", strings.Join(f.Nodes, " or "), t)
+	}
+
+	v.node(path, child)
+}
+
+// ranges checks that the children of a node are within its range and don't
+// overlap, in the source order of walk.Keys.
+func (v *validator) ranges(path string, n parser.Node, start int, end int) {
+	previousPath, previousEnd := "", start
+
+	for _, key := range walk.Keys[n.Type()] {
+		children := []parser.Node{}
+		paths := []string{}
+
+		switch value := n[key].(type) {
+		case parser.Node:
+			children, paths = append(children, value), append(paths, path+"."+key)
+		case []parser.Node:
+			for i, child := range value {
+				children, paths = append(children, child), append(paths, fmt.Sprintf("%s.%s[%d]", path, key, i))
+			}
+		}
+
+		for i, child := range children {
+			childStart, hasStart := child["start"].(int)
+			childEnd, hasEnd := child["end"].(int)
+			if child == nil || !hasStart || !hasEnd || childStart > childEnd {
+				continue
+			}
+
+			if childStart < start || childEnd > end {
+				v.errorf(paths[i], child, "range %d-%d is outside of its parent's range %d-%d", childStart, childEnd, start, end)
+			} else if childStart < previousEnd {
+				v.errorf(paths[i], child, "range %d-%d overlaps %s, which ends at %d", childStart, childEnd, previousPath, previousEnd)
+			}
+
+			previousPath, previousEnd = paths[i], childEnd
+		}
+	}
+}
+
+// allows reports whether a list of types and groups contains a type.
+func allows(names []string, t parser.Type) bool {
+	for _, name := range names {
+		if name == string(t) {
+			return true
+		}
+
+		for _, member := range Groups[name] {
+			if member == t {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isNull(v interface{}) bool {
+	n, ok := v.(parser.Node)
+
+	return v == nil || ok && n == nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(n parser.Node) []string {
+	keys := make([]string, 0, len(n))
+	for key := range n {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedFields(fields map[string]Field) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,149 @@
+package schema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/internal/testsource"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/schema"
+)
+
+func TestValidTrees(t *testing.T) {
+	sources := append([]testsource.Source{
+		{Src: `x = class {}; a;`, Options: parser.Options{Locations: true}},
+		{Src: `a; b c; d;`, Options: parser.Options{Tolerant: true}},
+	}, testsource.Sources...)
+
+	for _, s := range sources {
+		for _, err := range schema.Validate(testsource.Parse(t, s.Src, s.Options)) {
+			t.Errorf("%s: %v", s.Src, err)
+		}
+	}
+}
+
+func TestInvalidTrees(t *testing.T) {
+	tests := []struct {
+		src      string
+		options  parser.Options
+		change   func(program parser.Node)
+		expected []string
+	}{
+		{`a + b;`, parser.Options{}, func(program parser.Node) {
+			expression(program)["operator"] = "**"
+		}, []string{"Program.body[0].expression.operator: want one of + - * / == != === !== < > <= >=, got \"**\""}},
+		{`a = b;`, parser.Options{}, func(program parser.Node) {
+			expression(program)["left"] = parser.NewLiteral(0, 1, 1, "1")
+		}, []string{"Program.body[0].expression.left: want Identifier or MemberExpression, got Literal"}},
+		{`f(a);`, parser.Options{}, func(program parser.Node) {
+			expression(program)["arguments"] = []parser.Node{parser.NewEmptyStatement(2, 3)}
+		}, []string{"Program.body[0].expression.arguments[0]: want Expression, got EmptyStatement"}},
+		{`a;`, parser.Options{}, func(program parser.Node) {
+			program["body"] = []parser.Node{expression(program)}
+		}, []string{"Program.body[0]: want Statement, got Identifier"}},
+		{`if (a) b;`, parser.Options{}, func(program parser.Node) {
+			delete(program["body"].([]parser.Node)[0], "alternate")
+			program["body"].([]parser.Node)[0]["test"] = parser.Node(nil)
+		}, []string{
+			"Program.body[0].alternate: missing key of IfStatement",
+			"Program.body[0].test: want Expression, got null",
+		}},
+		{`a;`, parser.Options{}, func(program parser.Node) {
+			expression(program)["value"] = 1
+			expression(program)["name"] = 1
+		}, []string{
+			"Program.body[0].expression.value: unknown key of Identifier",
+			"Program.body[0].expression.name: want a string, got 1",
+		}},
+		{`a; b;`, parser.Options{}, func(program parser.Node) {
+			program["body"].([]parser.Node)[1]["start"] = 1
+		}, []string{"Program.body[1]: range 1-5 overlaps Program.body[0], which ends at 2"}},
+		{`a + b;`, parser.Options{}, func(program parser.Node) {
+			expression(program)["right"].(parser.Node)["end"] = 9
+		}, []string{
+			"Program.body[0].expression.right: range 4-9 is outside of its parent's range 0-5",
+		}},
+		{`a;`, parser.Options{}, func(program parser.Node) {
+			expression(program)["type"] = parser.Type("Wat")
+		}, []string{"Program.body[0].expression: unknown node type Wat"}},
+		{`a;`, parser.Options{}, func(program parser.Node) {
+			expression(program)["end"] = "1"
+		}, []string{`Program.body[0].expression: want int start and end, got 0 and "1"`}},
+		{`let a = 1;`, parser.Options{}, func(program parser.Node) {
+			declarator := program["body"].([]parser.Node)[0]["declarations"].([]parser.Node)[0]
+			delete(declarator, "init")
+			declarator["id"] = parser.NewLiteral(4, 5, 1, "1")
+		}, []string{
+			"Program.body[0].declarations[0].id: want Identifier, got Literal",
+			"Program.body[0].declarations[0].init: missing key of VariableDeclarator",
+		}},
+		{`class A extends B {}`, parser.Options{}, func(program parser.Node) {
+			class := program["body"].([]parser.Node)[0]
+			class["superClass"] = parser.NewEmptyStatement(16, 17)
+			delete(class, "body")
+		}, []string{
+			"Program.body[0].body: missing key of ClassDeclaration",
+			"Program.body[0].superClass: want Expression, got EmptyStatement",
+		}},
+		{`let a: T;`, parser.Options{TypeScript: true}, func(program parser.Node) {
+			id := program["body"].([]parser.Node)[0]["declarations"].([]parser.Node)[0]["id"].(parser.Node)
+			id["typeAnnotation"].(parser.Node)["typeAnnotation"].(parser.Node)["typeName"] = parser.NewLiteral(7, 8, "T", `"T"`)
+		}, []string{
+			"Program.body[0].declarations[0].id.typeAnnotation.typeAnnotation.typeName: want Identifier or TSQualifiedName, got Literal",
+		}},
+		{`<a b="c" />;`, parser.Options{JSX: true}, func(program parser.Node) {
+			opening := expression(program)["openingElement"].(parser.Node)
+			opening["attributes"] = []parser.Node{parser.NewIdentifier(3, 8, "b")}
+			opening["selfClosing"] = "yes"
+		}, []string{
+			"Program.body[0].expression.openingElement.attributes[0]: want JSXAttribute or JSXSpreadAttribute, got Identifier",
+			"Program.body[0].expression.openingElement.selfClosing: want a boolean, got \"yes\"",
+		}},
+	}
+
+	for _, test := range tests {
+		program := testsource.Parse(t, test.src, test.options)
+		test.change(program)
+
+		actual := []string{}
+		for _, err := range schema.Validate(program) {
+			actual = append(actual, err.Error())
+		}
+
+		if strings.Join(actual, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("Unexpected errors for %s.\nwant: %q\ngot: %q", test.src, test.expected, actual)
+		}
+	}
+}
+
+func expression(program parser.Node) parser.Node {
+	return program["body"].([]parser.Node)[0]["expression"].(parser.Node)
+}
+
+func TestSchemaCoversTypes(t *testing.T) {
+	for _, typ := range parser.Types {
+		if _, ok := schema.Nodes[typ]; !ok {
+			t.Errorf("Missing the node type %s", typ)
+		}
+	}
+
+	for typ, fields := range schema.Nodes {
+		for key, f := range fields {
+			for _, name := range f.Nodes {
+				if _, ok := schema.Groups[name]; !ok && schema.Nodes[parser.Type(name)] == nil {
+					t.Errorf("%s.%s refers to the unknown type %s", typ, key, name)
+				}
+			}
+		}
+	}
+}
+
+func ExampleValidate() {
+	program := parser.NewProgram(0, 3, parser.NewIdentifier(0, 1, "a"))
+
+	for _, err := range schema.Validate(program) {
+		fmt.Println(err)
+	}
+	// Output: Program.body[0]: want Statement, got Identifier
+}
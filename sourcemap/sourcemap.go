@@ -0,0 +1,360 @@
+// Package sourcemap generates source maps, in the version 3 format browsers
+// and tools read, mapping printed or transformed code back to its sources:
+//
+//	g := sourcemap.New("out.js")
+//	source := g.AddSource("in.js", src)
+//	code, err := g.Print(program, source, printer.DefaultOptions)
+//	code += sourcemap.InlineComment(g.Map())
+//
+// Nodes are mapped from their 'start' offsets in the parsed source, which are
+// in bytes, to where the printer puts them. Identifiers carry their names as
+// the source spells them, so renamed bindings map back to their old names.
+package sourcemap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+// Map is a source map, as serialized to JSON.
+type Map struct {
+	Version        int      `json:"version"`
+	File           string   `json:"file,omitempty"`
+	SourceRoot     string   `json:"sourceRoot,omitempty"`
+	Sources        []string `json:"sources"`
+	SourcesContent []string `json:"sourcesContent,omitempty"`
+	Names          []string `json:"names"`
+	// Mappings are the encoded mappings: a ';' separated group of ','
+	// separated segments for every line of the code.
+	Mappings string `json:"mappings"`
+}
+
+// Position is a position in code. Lines and columns are 0-based, and columns
+// count UTF-16 code units, as in source maps.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Mapping maps a position of the generated code to a position in a source.
+type Mapping struct {
+	Generated Position
+	// Source is the index of the source in Sources.
+	Source   int
+	Original Position
+	// Name is the name of the identifier at the original position, or empty.
+	Name string
+}
+
+// Generator builds a source map from mappings.
+type Generator struct {
+	file     string
+	sources  []string
+	contents []string
+	lines    []*tokenizer.LineIndex
+	mappings []Mapping
+}
+
+// New returns a generator for the map of a file.
+func New(file string) *Generator {
+	return &Generator{file: file}
+}
+
+// AddSource adds a source with its content and returns its index.
+func (g *Generator) AddSource(name string, content string) int {
+	g.sources = append(g.sources, name)
+	g.contents = append(g.contents, content)
+	g.lines = append(g.lines, tokenizer.NewLineIndex(content, tokenizer.Bytes))
+
+	return len(g.sources) - 1
+}
+
+// AddMapping adds a mapping. Mappings may be added in any order.
+func (g *Generator) AddMapping(m Mapping) {
+	g.mappings = append(g.mappings, m)
+}
+
+// Print prints a node parsed from a source like printer.Print does, and maps
+// the code to the source.
+func (g *Generator) Print(n parser.Node, source int, options printer.Options) (string, error) {
+	r := g.Recorder(source)
+
+	onNode := options.OnNode
+	options.OnNode = func(n parser.Node, offset int) {
+		r.OnNode(n, offset)
+		if onNode != nil {
+			onNode(n, offset)
+		}
+	}
+
+	code, err := printer.Print(n, options)
+	if err != nil {
+		return "", err
+	}
+	r.Done(code)
+
+	return code, nil
+}
+
+// Recorder records where a printer puts the nodes of a source, through the
+// OnNode option of the printer or of packages printing with it.
+type Recorder struct {
+	generator *Generator
+	source    int
+	nodes     []parser.Node
+	offsets   []int
+}
+
+// Recorder returns a recorder for the nodes of a source.
+func (g *Generator) Recorder(source int) *Recorder {
+	return &Recorder{generator: g, source: source}
+}
+
+// OnNode records that a node starts at a byte offset in the code.
+func (r *Recorder) OnNode(n parser.Node, offset int) {
+	r.nodes = append(r.nodes, n)
+	r.offsets = append(r.offsets, offset)
+}
+
+// Done adds the mappings of the recorded nodes, given the code they were
+// printed in, and forgets them. Nodes which don't span any of the source,
+// like the ones built by the builder package, aren't mapped.
+func (r *Recorder) Done(code string) {
+	g := r.generator
+	content, lines := g.contents[r.source], g.lines[r.source]
+	codeLines := tokenizer.NewLineIndex(code, tokenizer.Bytes)
+
+	for i, n := range r.nodes {
+		start, end := n.Start(), n.End()
+		if start >= end || end > len(content) {
+			continue
+		}
+
+		m := Mapping{
+			Generated: position(code, codeLines, r.offsets[i]),
+			Source:    r.source,
+			Original:  position(content, lines, start),
+		}
+		if n.Is(parser.Identifier) {
+			m.Name = identifierAt(content[start:end])
+		}
+
+		g.AddMapping(m)
+	}
+
+	r.nodes, r.offsets = nil, nil
+}
+
+// identifierAt returns the identifier a source starts with. The source of an
+// identifier node may go on with its type annotation.
+func identifierAt(src string) string {
+	end := strings.IndexFunc(src, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$'
+	})
+	if end == -1 {
+		return src
+	}
+
+	return src[:end]
+}
+
+// position converts a byte offset to a position.
+func position(src string, lines *tokenizer.LineIndex, offset int) Position {
+	p := lines.Position(offset)
+	lineStart := lines.LineStart(p.Line)
+
+	return Position{p.Line - 1, tokenizer.UTF16.FromBytes(src[lineStart:], offset-lineStart)}
+}
+
+// Map returns the source map of the mappings added so far. Of the mappings of
+// a generated position, the first one is kept, with a name if another one to
+// the same original position has one.
+func (g *Generator) Map() *Map {
+	mappings := append([]Mapping{}, g.mappings...)
+	sort.SliceStable(mappings, func(i, j int) bool {
+		return less(mappings[i].Generated, mappings[j].Generated)
+	})
+
+	kept := []Mapping{}
+	for _, m := range mappings {
+		last := len(kept) - 1
+		if last < 0 || kept[last].Generated != m.Generated {
+			kept = append(kept, m)
+		} else if kept[last].Name == "" && kept[last].Source == m.Source && kept[last].Original == m.Original {
+			kept[last].Name = m.Name
+		}
+	}
+
+	names, indices := []string{}, map[string]int{}
+	for _, m := range kept {
+		if _, ok := indices[m.Name]; m.Name != "" && !ok {
+			indices[m.Name] = len(names)
+			names = append(names, m.Name)
+		}
+	}
+
+	return &Map{
+		Version:        3,
+		File:           g.file,
+		Sources:        append([]string{}, g.sources...),
+		SourcesContent: append([]string{}, g.contents...),
+		Names:          names,
+		Mappings:       encodeMappings(kept, indices),
+	}
+}
+
+func less(a, b Position) bool {
+	return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+}
+
+// encodeMappings encodes sorted mappings. Each segment holds the generated
+// column, the source, the original line and column and, if there is one, the
+// name, as VLQs relative to the previous segment. Only the generated column
+// starts over on each line.
+func encodeMappings(mappings []Mapping, names map[string]int) string {
+	out := strings.Builder{}
+	line, column, source, originalLine, originalColumn, name := 0, 0, 0, 0, 0, 0
+
+	for i, m := range mappings {
+		if m.Generated.Line > line {
+			out.WriteString(strings.Repeat(";", m.Generated.Line-line))
+			line, column = m.Generated.Line, 0
+		} else if i > 0 {
+			out.WriteByte(',')
+		}
+
+		writeVLQ(&out, m.Generated.Column-column)
+		writeVLQ(&out, m.Source-source)
+		writeVLQ(&out, m.Original.Line-originalLine)
+		writeVLQ(&out, m.Original.Column-originalColumn)
+		column, source, originalLine, originalColumn = m.Generated.Column, m.Source, m.Original.Line, m.Original.Column
+
+		if m.Name != "" {
+			writeVLQ(&out, names[m.Name]-name)
+			name = names[m.Name]
+		}
+	}
+
+	return out.String()
+}
+
+const base64Digits = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// writeVLQ writes a number as a base64 VLQ: the sign in the lowest bit, then
+// five bits per digit from the lowest ones, with the sixth bit set on every
+// digit but the last.
+func writeVLQ(out *strings.Builder, v int) {
+	vlq := v << 1
+	if v < 0 {
+		vlq = -v<<1 | 1
+	}
+
+	for {
+		digit := vlq & 31
+		vlq >>= 5
+		if vlq > 0 {
+			digit |= 32
+		}
+		out.WriteByte(base64Digits[digit])
+
+		if vlq == 0 {
+			return
+		}
+	}
+}
+
+// Decode returns the mappings of the map. Segments without a source only
+// move the column, and give no mapping.
+func (m *Map) Decode() ([]Mapping, error) {
+	mappings := []Mapping{}
+	source, originalLine, originalColumn, name := 0, 0, 0, 0
+
+	for line, group := range strings.Split(m.Mappings, ";") {
+		column := 0
+		if group == "" {
+			continue
+		}
+
+		for _, segment := range strings.Split(group, ",") {
+			fields := []int{}
+			for rest := segment; rest != ""; {
+				v, n, err := readVLQ(rest)
+				if err != nil {
+					return nil, fmt.Errorf("sourcemap: segment %q: %w", segment, err)
+				}
+				fields = append(fields, v)
+				rest = rest[n:]
+			}
+
+			if len(fields) != 1 && len(fields) != 4 && len(fields) != 5 {
+				return nil, fmt.Errorf("sourcemap: segment %q has %d fields", segment, len(fields))
+			}
+
+			column += fields[0]
+			if len(fields) == 1 {
+				// The code from the column on maps to no source.
+				continue
+			}
+
+			source += fields[1]
+			originalLine += fields[2]
+			originalColumn += fields[3]
+			mapping := Mapping{Position{line, column}, source, Position{originalLine, originalColumn}, ""}
+
+			if source < 0 || source >= len(m.Sources) {
+				return nil, fmt.Errorf("sourcemap: segment %q: no source %d", segment, source)
+			}
+			if len(fields) == 5 {
+				name += fields[4]
+				if name < 0 || name >= len(m.Names) {
+					return nil, fmt.Errorf("sourcemap: segment %q: no name %d", segment, name)
+				}
+				mapping.Name = m.Names[name]
+			}
+
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	return mappings, nil
+}
+
+// readVLQ reads a base64 VLQ and returns it with the number of characters it
+// takes.
+func readVLQ(s string) (int, int, error) {
+	vlq, shift := 0, 0
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base64Digits, s[i])
+		if digit < 0 {
+			return 0, 0, fmt.Errorf("invalid base64 digit %q", s[i])
+		}
+
+		vlq |= digit & 31 << shift
+		shift += 5
+		if digit&32 == 0 {
+			if vlq&1 == 1 {
+				return -(vlq >> 1), i + 1, nil
+			}
+
+			return vlq >> 1, i + 1, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("unterminated VLQ")
+}
+
+// InlineComment returns a line comment embedding a map in the code it maps,
+// as a base64 data URL. It goes on the last line of the code.
+func InlineComment(m *Map) string {
+	data, _ := json.Marshal(m)
+
+	return "//# sourceMappingURL=data:application/json;charset=utf-8;base64," + base64.StdEncoding.EncodeToString(data) + "\n"
+}
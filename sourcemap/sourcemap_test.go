@@ -0,0 +1,230 @@
+package sourcemap_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/0xvesion/go-js-parser/minify"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/printer"
+	"github.com/0xvesion/go-js-parser/sourcemap"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+)
+
+func parse(t *testing.T, src string) parser.Node {
+	t.Helper()
+
+	n, err := parser.New(tokenizer.New(src)).Parse()
+	if err != nil {
+		t.Fatalf("%s: %v", src, err)
+	}
+
+	return n
+}
+
+func TestMappings(t *testing.T) {
+	tests := []struct {
+		mappings []sourcemap.Mapping
+		expected string
+	}{
+		{[]sourcemap.Mapping{{}}, "AAAA"},
+		{[]sourcemap.Mapping{
+			{Generated: sourcemap.Position{Line: 0, Column: 16}, Original: sourcemap.Position{Line: 2, Column: 1}},
+			{Generated: sourcemap.Position{Line: 0, Column: 0}, Original: sourcemap.Position{Line: 3, Column: 123}, Name: "a"},
+		}, "AAG2HA,gBAD1H"},
+		{[]sourcemap.Mapping{
+			{Generated: sourcemap.Position{Line: 2, Column: 4}, Source: 1, Name: "b"},
+			{Generated: sourcemap.Position{Line: 0, Column: 2}, Name: "a"},
+		}, "EAAAA;;ICAAC"},
+	}
+
+	for _, test := range tests {
+		g := sourcemap.New("out.js")
+		g.AddSource("a.js", "")
+		g.AddSource("b.js", "")
+		for _, m := range test.mappings {
+			g.AddMapping(m)
+		}
+
+		m := g.Map()
+		if m.Mappings != test.expected {
+			t.Errorf("Unexpected mappings.\nwant: %s\ngot:  %s", test.expected, m.Mappings)
+		}
+
+		decoded, err := m.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != len(test.mappings) {
+			t.Errorf("Decoding %s gave %v", m.Mappings, decoded)
+		}
+	}
+}
+
+// checkNames checks that the names of the mappings are where they point to in
+// the generated code and in the source.
+func checkNames(t *testing.T, m *sourcemap.Map, code string, renamed bool) {
+	t.Helper()
+
+	mappings, err := m.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	named := 0
+	for _, mapping := range mappings {
+		if mapping.Name == "" {
+			continue
+		}
+		named++
+
+		if source := at(m.SourcesContent[mapping.Source], mapping.Original); !strings.HasPrefix(source, mapping.Name) {
+			t.Errorf("%s maps to %q in the source", mapping.Name, source)
+		}
+
+		if generated := at(code, mapping.Generated); !renamed && !strings.HasPrefix(generated, mapping.Name) {
+			t.Errorf("%s maps from %q in the code:\n%s", mapping.Name, generated, code)
+		}
+	}
+
+	if named == 0 {
+		t.Errorf("No names in %v", mappings)
+	}
+}
+
+// at returns the code from a position on.
+func at(code string, p sourcemap.Position) string {
+	line := strings.Split(code, "\n")[p.Line]
+	units := utf16.Encode([]rune(line))
+
+	return string(utf16.Decode(units[p.Column:]))
+}
+
+func TestPrint(t *testing.T) {
+	src := "let answer = 42, other;\nfunction ask(question) {\n  return question + answer; }\n/* ☃ */ ask('ünïcode', ask(1, 2), other);\n(answer || other).length;"
+
+	for _, options := range []printer.Options{
+		printer.DefaultOptions,
+		{Indent: "\t", Width: 20, Semicolons: printer.SemicolonsAsNeeded, Source: src},
+		{Compact: true},
+	} {
+		g := sourcemap.New("out.js")
+		source := g.AddSource("in.js", src)
+
+		code, err := g.Print(parse(t, src), source, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkNames(t, g.Map(), code, false)
+	}
+
+	g := sourcemap.New("out.js")
+	code, err := g.Print(parse(t, src), g.AddSource("in.js", src), printer.DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mappings, _ := g.Map().Decode()
+	expected := sourcemap.Mapping{
+		Generated: sourcemap.Position{Line: 4, Column: 0},
+		Original:  sourcemap.Position{Line: 3, Column: 8},
+		Name:      "ask",
+	}
+	found := false
+	for _, m := range mappings {
+		found = found || m == expected
+	}
+	if !found {
+		t.Errorf("Expected %v in %v for:\n%s", expected, mappings, code)
+	}
+}
+
+func TestMinify(t *testing.T) {
+	src := "function outer(first, second) {\n  let sum = first + second;\n  return sum * sum;\n}"
+
+	g := sourcemap.New("out.min.js")
+	r := g.Recorder(g.AddSource("in.js", src))
+
+	options := minify.DefaultOptions
+	options.OnNode = r.OnNode
+	code, err := minify.Print(parse(t, src), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Done(code)
+
+	m := g.Map()
+	checkNames(t, m, code, true)
+
+	for _, name := range []string{"outer", "first", "second", "sum"} {
+		found := false
+		for _, n := range m.Names {
+			found = found || n == name
+		}
+		if !found {
+			t.Errorf("Expected %s in the names %v", name, m.Names)
+		}
+	}
+}
+
+func TestInlineComment(t *testing.T) {
+	g := sourcemap.New("out.js")
+	g.AddMapping(sourcemap.Mapping{Name: "a"})
+	g.AddSource("in.js", "a;")
+	m := g.Map()
+
+	comment := sourcemap.InlineComment(m)
+	prefix := "//# sourceMappingURL=data:application/json;charset=utf-8;base64,"
+	if !strings.HasPrefix(comment, prefix) || !strings.HasSuffix(comment, "\n") {
+		t.Fatalf("Unexpected comment: %s", comment)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(comment[len(prefix):], "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &sourcemap.Map{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, m) {
+		t.Errorf("Unexpected map: %s", data)
+	}
+
+	expected := `{"version":3,"file":"out.js","sources":["in.js"],"sourcesContent":["a;"],"names":["a"],"mappings":"AAAAA"}`
+	if string(data) != expected {
+		t.Errorf("Unexpected JSON.\nwant: %s\ngot:  %s", expected, data)
+	}
+}
+
+func TestDecodeUnmapped(t *testing.T) {
+	m := &sourcemap.Map{Version: 3, Sources: []string{"a.js"}, Mappings: "AAAA,E,CACA;A"}
+
+	mappings, err := m.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []sourcemap.Mapping{
+		{Generated: sourcemap.Position{Line: 0, Column: 0}},
+		{Generated: sourcemap.Position{Line: 0, Column: 3}, Original: sourcemap.Position{Line: 1, Column: 0}},
+	}
+	if !reflect.DeepEqual(mappings, expected) {
+		t.Errorf("Unexpected mappings.\nwant: %v\ngot:  %v", expected, mappings)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, mappings := range []string{"AA", "AAAAAA", "AA!A", "AAAg", "ACAA", "AAAAC"} {
+		m := &sourcemap.Map{Version: 3, Sources: []string{"a.js"}, Mappings: mappings}
+		if _, err := m.Decode(); err == nil {
+			t.Errorf("Expected an error for %q", mappings)
+		}
+	}
+}
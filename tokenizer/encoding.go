@@ -0,0 +1,116 @@
+package tokenizer
+
+import "unicode/utf8"
+
+// Encoding is the unit in which offsets into a source are counted.
+type Encoding int
+
+const (
+	// Bytes counts UTF-8 bytes, the native offsets of Go strings.
+	Bytes Encoding = iota
+	// UTF16 counts UTF-16 code units, as JavaScript, acorn, editors and
+	// source maps do.
+	UTF16
+	// CodePoints counts Unicode code points.
+	CodePoints
+)
+
+// width returns the number of units a rune of size bytes takes.
+func (e Encoding) width(r rune, size int) int {
+	switch e {
+	case UTF16:
+		if r >= 0x10000 {
+			return 2
+		}
+
+		return 1
+	case CodePoints:
+		return 1
+	}
+
+	return size
+}
+
+// FromBytes converts a byte offset into src to an offset in the encoding.
+func (e Encoding) FromBytes(src string, offset int) int {
+	if e == Bytes {
+		return offset
+	}
+
+	units := 0
+	for i := 0; i < offset && i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		units += e.width(r, size)
+		i += size
+	}
+
+	return units
+}
+
+// ToBytes converts an offset in the encoding into src to a byte offset. An
+// offset inside a character maps to the start of the character.
+func (e Encoding) ToBytes(src string, offset int) int {
+	if e == Bytes {
+		return offset
+	}
+
+	i, units := 0, 0
+	for i < len(src) {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		units += e.width(r, size)
+		if units > offset {
+			break
+		}
+		i += size
+	}
+
+	return i
+}
+
+// ConvertOffset converts an offset into src from one encoding to another.
+func ConvertOffset(src string, offset int, from Encoding, to Encoding) int {
+	return to.FromBytes(src, from.ToBytes(src, offset))
+}
+
+// offsetTable maps the byte offsets of a source to offsets in an encoding. A
+// nil table is the identity, used for byte offsets and ASCII sources.
+type offsetTable []int
+
+func newOffsetTable(src string, e Encoding) offsetTable {
+	if e == Bytes || isASCII(src) {
+		return nil
+	}
+
+	table := make(offsetTable, len(src)+1)
+	units := 0
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		for j := 0; j < size; j++ {
+			table[i+j] = units
+		}
+
+		units += e.width(r, size)
+		i += size
+	}
+	table[len(src)] = units
+
+	return table
+}
+
+func (t offsetTable) offset(cursor int) int {
+	if t == nil {
+		return cursor
+	}
+
+	return t[cursor]
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
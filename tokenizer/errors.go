@@ -0,0 +1,26 @@
+package tokenizer
+
+import "fmt"
+
+// LexErrorKind classifies the errors of the tokenizer.
+type LexErrorKind int
+
+const (
+	UnknownCharacter LexErrorKind = iota
+	UnterminatedString
+	UnterminatedComment
+	InvalidEscape
+	InvalidJSXText
+)
+
+// LexError is the error returned by Next for invalid input. Offset is counted
+// in the encoding of the tokenizer.
+type LexError struct {
+	Kind    LexErrorKind
+	Offset  int
+	Message string
+}
+
+func (e *LexError) Error() string {
+	return fmt.Sprintf("%s at %d", e.Message, e.Offset)
+}
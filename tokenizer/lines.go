@@ -0,0 +1,65 @@
+package tokenizer
+
+import (
+	"sort"
+	"strings"
+)
+
+// Position is a line and column in a source. Lines are 1-based and columns
+// are 0-based, as in ESTree.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// LineIndex converts offsets into a source to positions. It records where
+// every line starts, so each lookup is a binary search instead of a scan.
+type LineIndex struct {
+	starts []int
+}
+
+// NewLineIndex indexes the lines of src, for offsets and columns counted in
+// the given encoding. Lines end at "\n", "\r\n", "\r", U+2028 and U+2029,
+// like in JavaScript.
+func NewLineIndex(src string, encoding Encoding) *LineIndex {
+	starts := []int{0}
+
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == '\r' && i+1 < len(src) && src[i+1] == '\n':
+			i++
+			starts = append(starts, i+1)
+		case src[i] == '\n' || src[i] == '\r':
+			starts = append(starts, i+1)
+		case strings.HasPrefix(src[i:], "\u2028") || strings.HasPrefix(src[i:], "\u2029"):
+			i += 2
+			starts = append(starts, i+1)
+		}
+	}
+
+	offsets := newOffsetTable(src, encoding)
+	for i, start := range starts {
+		starts[i] = offsets.offset(start)
+	}
+
+	return &LineIndex{starts}
+}
+
+// Position returns the position of an offset.
+func (l *LineIndex) Position(offset int) Position {
+	line := sort.Search(len(l.starts), func(i int) bool {
+		return l.starts[i] > offset
+	})
+
+	return Position{line, offset - l.starts[line-1]}
+}
+
+// LineStart returns the offset at which a 1-based line starts.
+func (l *LineIndex) LineStart(line int) int {
+	return l.starts[line-1]
+}
+
+// Lines returns the number of lines.
+func (l *LineIndex) Lines() int {
+	return len(l.starts)
+}
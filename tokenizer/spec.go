@@ -1,5 +1,7 @@
 package tokenizer
 
+import "regexp"
+
 type Type string
 
 const (
@@ -42,6 +44,17 @@ const (
 	SuperKeyword                    = "SuperKeyword"
 	GetKeyword                      = "GetKeyword"
 	SetKeyword                      = "SetKeyword"
+	Colon                           = "Colon"
+	Spread                          = "Spread"
+	JSXIdentifier                   = "JSXIdentifier"
+	JSXText                         = "JSXText"
+	JSXTagStart                     = "JSXTagStart"
+	JSXTagEnd                       = "JSXTagEnd"
+	QuestionMark                    = "QuestionMark"
+	Arrow                           = "Arrow"
+	BitwiseOrOperator               = "BitwiseOrOperator"
+	BitwiseAndOperator              = "BitwiseAndOperator"
+	At                              = "At"
 )
 
 type specEntry struct {
@@ -49,22 +62,48 @@ type specEntry struct {
 	Regexp []string
 }
 
+type compiledSpecEntry struct {
+	Type
+	regexps []*regexp.Regexp
+}
+
+// compile compiles the expressions of a spec once, anchored to the start of
+// the remaining source.
+func compile(spec []specEntry) []compiledSpecEntry {
+	compiled := make([]compiledSpecEntry, len(spec))
+	for i, entry := range spec {
+		compiled[i].Type = entry.Type
+		for _, expr := range entry.Regexp {
+			compiled[i].regexps = append(compiled[i].regexps, regexp.MustCompile(`^(?:`+expr+`)`))
+		}
+	}
+
+	return compiled
+}
+
 var spec = []specEntry{
-	{None, []string{`\s+`, `\/\*[\s\S]*\*\/`, `\/\/.*`}},
+	{None, []string{`\s+`, `\/\*[\s\S]*?\*\/`, `\/\/.*`}},
 	{Number, []string{`\d+`}},
-	{String, []string{`\".*\"`, `\'.*\'`}},
+	{String, []string{`"([^"\\\n\r]|\\(\r\n|[\s\S]))*"`, `'([^'\\\n\r]|\\(\r\n|[\s\S]))*'`}},
 	{Semicolon, []string{`;`}},
 	{Comma, []string{`,`}},
+	{Colon, []string{`:`}},
+	{QuestionMark, []string{`\?`}},
+	{At, []string{`@`}},
 	{OpeningCurlyBrace, []string{`{`}},
 	{ClosingCurlyBrace, []string{`}`}},
+	{Spread, []string{`\.\.\.`}},
 	{Dot, []string{`\.`}},
 	{OpeningBracket, []string{`\[`}},
 	{ClosingBracket, []string{`\]`}},
 	{LogicalOrOperator, []string{`\|\|`}},
 	{LogicalAndOperator, []string{`&&`}},
+	{BitwiseOrOperator, []string{`\|`}},
+	{BitwiseAndOperator, []string{`&`}},
 	{EqualityOperator, []string{`[!=]==?`}},
 	{LogicalNotOperator, []string{`!`}},
 	{RelationalOperator, []string{`[><]=?`}},
+	{Arrow, []string{`=>`}},
 	{SimpleAssignmentOperator, []string{`=`}},
 	{ComplexAssignmentOperator, []string{`[-+*/]=`}},
 	{AdditiveOperator, []string{`[\+-]`}},
@@ -90,3 +129,26 @@ var spec = []specEntry{
 	{NullLiteral, []string{`\bnull\b`}},
 	{Identifier, []string{`[a-zA-Z_$]\w*`}},
 }
+
+// jsxTagSpec is used between the angle brackets of a JSX tag, where names may
+// contain dashes and strings have no escape sequences.
+var jsxTagSpec = []specEntry{
+	{None, []string{`\s+`, `\/\*[\s\S]*?\*\/`, `\/\/.*`}},
+	{String, []string{`"[^"]*"`, `'[^']*'`}},
+	{JSXIdentifier, []string{`[a-zA-Z_$][\w$-]*`}},
+	{OpeningCurlyBrace, []string{`{`}},
+	{ClosingCurlyBrace, []string{`}`}},
+	{Dot, []string{`\.`}},
+	{Colon, []string{`:`}},
+	{SimpleAssignmentOperator, []string{`=`}},
+	{MultiplicativeOperator, []string{`\/`}},
+	{JSXTagStart, []string{`<`}},
+	{JSXTagEnd, []string{`>`}},
+}
+
+// jsxChildSpec is used between the tags of a JSX element.
+var jsxChildSpec = []specEntry{
+	{OpeningCurlyBrace, []string{`{`}},
+	{JSXTagStart, []string{`<`}},
+	{JSXText, []string{`[^<{]+`}},
+}
@@ -2,7 +2,10 @@ package tokenizer
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type Token struct {
@@ -26,21 +29,57 @@ func (to Token) Not(types ...Type) bool {
 	return !to.Is(types...)
 }
 
+// Context selects the lexical grammar used for the following tokens. The
+// parser switches it before consuming the token preceding a JSX tag or child.
+type Context int
+
+const (
+	DefaultContext Context = iota
+	JSXTagContext
+	JSXChildContext
+)
+
+var compiledSpecs = map[Context][]compiledSpecEntry{
+	DefaultContext:  compile(spec),
+	JSXTagContext:   compile(jsxTagSpec),
+	JSXChildContext: compile(jsxChildSpec),
+}
+
 type tokenizer struct {
-	src    string
-	cursor int
+	src      string
+	cursor   int
+	context  Context
+	encoding Encoding
+	offsets  offsetTable
 }
 
 type Tokenizer interface {
 	HasNext() bool
+	// Next returns the following token. After an error, it resumes after the
+	// invalid input.
 	Next() (Token, error)
 	Src() string
 	Cursor() int
+	// Seek moves the cursor back to a previous position, so the parser can
+	// backtrack after a speculative parse.
+	Seek(cursor int)
+	SetContext(Context)
+	// Encoding is the unit of the token offsets. The cursor always counts
+	// bytes.
+	Encoding() Encoding
 }
 
 func New(src string) Tokenizer {
+	return NewWithEncoding(src, Bytes)
+}
+
+// NewWithEncoding returns a tokenizer whose token offsets are counted in the
+// given encoding.
+func NewWithEncoding(src string, encoding Encoding) Tokenizer {
 	return &tokenizer{
-		src: src,
+		src:      src,
+		encoding: encoding,
+		offsets:  newOffsetTable(src, encoding),
 	}
 }
 
@@ -52,39 +91,150 @@ func (t *tokenizer) Src() string {
 	return t.src
 }
 
+func (t *tokenizer) Seek(cursor int) {
+	t.cursor = cursor
+}
+
+func (t *tokenizer) SetContext(c Context) {
+	t.context = c
+}
+
+func (t *tokenizer) Encoding() Encoding {
+	return t.encoding
+}
+
 func (t *tokenizer) HasNext() bool {
 	return t.cursor < len(t.src)
 }
 
 func (t *tokenizer) Next() (Token, error) {
-	if !t.HasNext() {
-		return Token{}, nil
-	}
+	for t.HasNext() {
+		s := t.src[t.cursor:]
+		tokenStart := t.cursor
 
-	s := t.src[t.cursor:]
-	tokenStart := t.cursor
-	for _, current := range spec {
-		for _, expr := range current.Regexp {
-			r, err := regexp.Compile(expr)
-			if err != nil {
-				return Token{}, nil
+		if t.context != JSXChildContext && strings.HasPrefix(s, "/*") && !strings.Contains(s[2:], "*/") {
+			t.cursor = len(t.src)
+
+			return Token{}, t.error(UnterminatedComment, tokenStart, "unterminated comment")
+		}
+
+		tokenType, length := t.match(s)
+		if length == 0 {
+			return Token{}, t.invalidToken(s)
+		}
+
+		match := s[:length]
+		t.cursor += length
+
+		switch tokenType {
+		case None:
+			continue
+		case String:
+			if t.context != DefaultContext {
+				break
 			}
 
-			loc := r.FindStringIndex(s)
-			if loc == nil || loc[0] != 0 {
-				continue
+			if i := invalidEscape(match); i != -1 {
+				return Token{}, t.error(InvalidEscape, tokenStart+i, "invalid escape sequence")
+			}
+		case JSXText:
+			if i := strings.IndexAny(match, ">}"); i != -1 {
+				return Token{}, t.error(InvalidJSXText, tokenStart+i, fmt.Sprintf("unexpected token %q in JSX text", match[i]))
+			}
+		}
+
+		return Token{tokenType, match, t.offsets.offset(tokenStart), t.offsets.offset(t.cursor)}, nil
+	}
+
+	return Token{}, nil
+}
+
+// match returns the type and length of the token at the start of s, or a zero
+// length if no token matches.
+func (t *tokenizer) match(s string) (Type, int) {
+	for _, current := range compiledSpecs[t.context] {
+		for _, r := range current.regexps {
+			if loc := r.FindStringIndex(s); loc != nil {
+				return current.Type, loc[1]
 			}
+		}
+	}
+
+	return None, 0
+}
+
+// invalidToken explains why no token matches at the start of s and skips the
+// invalid input, so tokenizing can resume.
+func (t *tokenizer) invalidToken(s string) error {
+	start := t.cursor
+
+	if (s[0] == '"' || s[0] == '\'') && t.context != JSXChildContext {
+		if end := strings.IndexAny(s, "\r\n"); end != -1 {
+			t.cursor += end
+		} else {
+			t.cursor = len(t.src)
+		}
+
+		return t.error(UnterminatedString, start, "unterminated string")
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	t.cursor += size
 
-			match := s[loc[0]:loc[1]]
-			t.cursor += loc[1]
+	return t.error(UnknownCharacter, start, fmt.Sprintf("unknown character %q", r))
+}
+
+func (t *tokenizer) error(kind LexErrorKind, cursor int, message string) *LexError {
+	return &LexError{kind, t.offsets.offset(cursor), message}
+}
 
-			if current.Type == None {
-				return t.Next()
+// invalidEscape returns the index of the first malformed \x or \u escape
+// sequence in a string literal, or -1.
+func invalidEscape(s string) int {
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != '\\' {
+			continue
+		}
+
+		switch rest := s[i+2:]; s[i+1] {
+		case 'x':
+			if !hasHexPrefix(rest, 2) {
+				return i
 			}
+		case 'u':
+			if !strings.HasPrefix(rest, "{") {
+				if !hasHexPrefix(rest, 4) {
+					return i
+				}
+				break
+			}
+
+			end := strings.IndexByte(rest, '}')
+			if end < 2 || !hasHexPrefix(rest[1:], end-1) {
+				return i
+			}
+			if value, err := strconv.ParseUint(rest[1:end], 16, 32); err != nil || value > unicode.MaxRune {
+				return i
+			}
+		}
+
+		i++
+	}
+
+	return -1
+}
+
+// hasHexPrefix reports whether s starts with n hexadecimal digits.
+func hasHexPrefix(s string, n int) bool {
+	if len(s) < n {
+		return false
+	}
 
-			return Token{current.Type, match, tokenStart, t.cursor}, nil
+	for i := 0; i < n; i++ {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", rune(s[i])) {
+			return false
 		}
 	}
 
-	return Token{}, fmt.Errorf("unknown token: %s", s)
+	return true
 }
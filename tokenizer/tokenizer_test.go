@@ -1,6 +1,7 @@
 package tokenizer
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -62,6 +63,8 @@ func TestSkipComments(t *testing.T) {
 func TestRecognizesStrings(t *testing.T) {
 	tokenizerTest(t, `'Hello World!'`, []Token{{String, `'Hello World!'`, 0, 14}})
 	tokenizerTest(t, `"Hello World!"`, []Token{{String, `"Hello World!"`, 0, 14}})
+	tokenizerTest(t, `"a\"b"`, []Token{{String, `"a\"b"`, 0, 6}})
+	tokenizerTest(t, `'a';'b'`, []Token{{String, `'a'`, 0, 3}, {Semicolon, `;`, 3, 4}, {String, `'b'`, 4, 7}})
 }
 
 func TestRecognizesSemicolon(t *testing.T) {
@@ -159,3 +162,167 @@ func TestRecognizesBrackets(t *testing.T) {
 	tokenizerTest(t, `[`, []Token{{OpeningBracket, `[`, 0, 1}})
 	tokenizerTest(t, `]`, []Token{{ClosingBracket, `]`, 0, 1}})
 }
+
+func TestJSXContexts(t *testing.T) {
+	src := `<a-b c="&amp;">x &gt; y{`
+	tag, err := all(&tokenizer{src: src[:15], context: JSXTagContext})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Token{
+		{JSXTagStart, `<`, 0, 1},
+		{JSXIdentifier, `a-b`, 1, 4},
+		{JSXIdentifier, `c`, 5, 6},
+		{SimpleAssignmentOperator, `=`, 6, 7},
+		{String, `"&amp;"`, 7, 14},
+		{JSXTagEnd, `>`, 14, 15},
+	}
+	if !reflect.DeepEqual(expected, tag) {
+		t.Errorf("Unexpected result. want: %v got: %v", expected, tag)
+	}
+
+	children, err := all(&tokenizer{src: src, cursor: 15, context: JSXChildContext})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []Token{{JSXText, `x &gt; y`, 15, 23}, {OpeningCurlyBrace, `{`, 23, 24}}
+	if !reflect.DeepEqual(expected, children) {
+		t.Errorf("Unexpected result. want: %v got: %v", expected, children)
+	}
+}
+
+func TestDecodeEntities(t *testing.T) {
+	for src, expected := range map[string]string{
+		`a &amp; b`:       `a & b`,
+		`&lt;&#62;&#x41;`: `<>A`,
+		`&nbsp;`:          " ",
+		`&unknown; & &;`:  `&unknown; & &;`,
+	} {
+		if actual := DecodeEntities(src); actual != expected {
+			t.Errorf("Unexpected result for %q. want: %q got: %q", src, expected, actual)
+		}
+	}
+}
+
+func TestRecognizesTypeScriptPunctuators(t *testing.T) {
+	tokenizerTest(t, `:`, []Token{{Colon, `:`, 0, 1}})
+	tokenizerTest(t, `?`, []Token{{QuestionMark, `?`, 0, 1}})
+	tokenizerTest(t, `=>`, []Token{{Arrow, `=>`, 0, 2}})
+	tokenizerTest(t, `|`, []Token{{BitwiseOrOperator, `|`, 0, 1}})
+	tokenizerTest(t, `&`, []Token{{BitwiseAndOperator, `&`, 0, 1}})
+	tokenizerTest(t, `...`, []Token{{Spread, `...`, 0, 3}})
+}
+
+func TestRecognizesAt(t *testing.T) {
+	tokenizerTest(t, `@a`, []Token{{At, `@`, 0, 1}, {Identifier, `a`, 1, 2}})
+}
+
+func TestLineIndex(t *testing.T) {
+	index := NewLineIndex("a\nbc\r\nd\re\u2028f", Bytes)
+
+	tests := []struct {
+		offset   int
+		expected Position
+	}{
+		{0, Position{1, 0}},
+		{1, Position{1, 1}},
+		{2, Position{2, 0}},
+		{4, Position{2, 2}},
+		{6, Position{3, 0}},
+		{8, Position{4, 0}},
+		{12, Position{5, 0}},
+		{13, Position{5, 1}},
+	}
+
+	for _, test := range tests {
+		if actual := index.Position(test.offset); actual != test.expected {
+			t.Errorf("Invalid position of offset %d. want: %v got: %v", test.offset, test.expected, actual)
+		}
+	}
+}
+
+func TestEncodings(t *testing.T) {
+	src := "a€😀b"
+
+	tests := []struct {
+		encoding Encoding
+		offsets  []int
+	}{
+		{Bytes, []int{0, 1, 4, 8, 9}},
+		{UTF16, []int{0, 1, 2, 4, 5}},
+		{CodePoints, []int{0, 1, 2, 3, 4}},
+	}
+
+	bytes := tests[0].offsets
+	for _, test := range tests {
+		for i, offset := range test.offsets {
+			if actual := test.encoding.FromBytes(src, bytes[i]); actual != offset {
+				t.Errorf("Invalid offset of byte %d in encoding %d. want: %d got: %d", bytes[i], test.encoding, offset, actual)
+			}
+			if actual := test.encoding.ToBytes(src, offset); actual != bytes[i] {
+				t.Errorf("Invalid byte of offset %d in encoding %d. want: %d got: %d", offset, test.encoding, bytes[i], actual)
+			}
+		}
+	}
+
+	if actual := ConvertOffset(src, 4, UTF16, CodePoints); actual != 3 {
+		t.Errorf("Invalid converted offset. want: 3 got: %d", actual)
+	}
+	if actual := UTF16.ToBytes(src, 3); actual != 4 {
+		t.Errorf("Expected an offset inside a surrogate pair to map to the character. got: %d", actual)
+	}
+}
+
+func TestTokenOffsetsInEncoding(t *testing.T) {
+	tokenizer := NewWithEncoding(`"😀" x`, UTF16)
+
+	expected := []Token{{String, `"😀"`, 0, 4}, {Identifier, `x`, 5, 6}}
+	for _, token := range expected {
+		actual, err := tokenizer.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != token {
+			t.Errorf("Invalid token. want: %v got: %v", token, actual)
+		}
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		kind   LexErrorKind
+		offset int
+	}{
+		{`x #`, UnknownCharacter, 2},
+		{`x = "abc`, UnterminatedString, 4},
+		{"'a\nb'", UnterminatedString, 0},
+		{`/* a`, UnterminatedComment, 0},
+		{`"\x4"`, InvalidEscape, 1},
+		{`"a\u12"`, InvalidEscape, 2},
+		{`"\u{110000}"`, InvalidEscape, 1},
+		{`"\u{}"`, InvalidEscape, 1},
+	}
+
+	for _, test := range tests {
+		_, err := all(New(test.src).(*tokenizer))
+
+		var lexErr *LexError
+		if !errors.As(err, &lexErr) {
+			t.Errorf("Expected a LexError for %q, got: %v", test.src, err)
+			continue
+		}
+
+		if lexErr.Kind != test.kind || lexErr.Offset != test.offset {
+			t.Errorf("Invalid error for %q. want: %d at %d got: %d at %d", test.src, test.kind, test.offset, lexErr.Kind, lexErr.Offset)
+		}
+	}
+}
+
+func TestRecognizesValidEscapes(t *testing.T) {
+	tokenizerTest(t, `"\x41A\u{1F600}\q"`, []Token{{String, `"\x41A\u{1F600}\q"`, 0, 18}})
+	tokenizerTest(t, "'a\\\nb'", []Token{{String, "'a\\\nb'", 0, 6}})
+	tokenizerTest(t, `/* a */ 1 /* b */`, []Token{{Number, `1`, 8, 9}, {None, ``, 0, 0}})
+}
@@ -0,0 +1,152 @@
+package transform
+
+import "github.com/0xvesion/go-js-parser/parser"
+
+// Scope is a lexical scope: the program, a function, a block or a 'for'
+// statement, with the names declared in it.
+type Scope struct {
+	// Node is the node creating the scope.
+	Node   parser.Node
+	Parent *Scope
+	// Bindings map the declared names to the identifiers declaring them.
+	// 'var' and function declarations are hoisted to the nearest function
+	// or the program.
+	Bindings map[string]parser.Node
+}
+
+// Lookup returns the scope declaring a name, or nil for globals.
+func (s *Scope) Lookup(name string) *Scope {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if _, ok := scope.Bindings[name]; ok {
+			return scope
+		}
+	}
+
+	return nil
+}
+
+// HasBinding reports whether a name is declared in the scope or around it.
+func (s *Scope) HasBinding(name string) bool {
+	return s.Lookup(name) != nil
+}
+
+// Scope returns the innermost scope around the node, or created by it. It is
+// built on first use and doesn't see later changes to the tree.
+func (p *NodePath) Scope() *Scope {
+	for path := p; path != nil; path = path.Parent {
+		if !createsScope(path) {
+			continue
+		}
+
+		if path.scope == nil {
+			path.scope = &Scope{Node: path.Node, Bindings: map[string]parser.Node{}}
+			if path.Parent != nil {
+				path.scope.Parent = path.Parent.Scope()
+			}
+			path.scope.declare(path.Node)
+		}
+
+		return path.scope
+	}
+
+	return nil
+}
+
+func createsScope(p *NodePath) bool {
+	switch {
+	case p.Node.Is(parser.Program, parser.ForStatement) || isFunction(p.Node):
+		return true
+	case p.Node.Is(parser.BlockStatement):
+		// The body of a function shares the scope of its parameters.
+		return p.Parent == nil || !isFunction(p.Parent.Node)
+	}
+
+	return false
+}
+
+func isFunction(n parser.Node) bool {
+	return n.Is(parser.FunctionDeclaration, parser.FunctionExpression, parser.TSEmptyBodyFunctionExpression)
+}
+
+// declare binds the names declared by the node creating the scope.
+func (s *Scope) declare(n parser.Node) {
+	switch {
+	case isFunction(n):
+		// A function expression's own name is only visible inside it.
+		if id, ok := n["id"].(parser.Node); ok && id != nil && n.Is(parser.FunctionExpression) {
+			s.bind(id)
+		}
+		for _, param := range n["params"].([]parser.Node) {
+			if param.Is(parser.TSParameterProperty) {
+				param = param["parameter"].(parser.Node)
+			}
+			s.bind(param)
+		}
+		if body, ok := n["body"].(parser.Node); ok && body != nil {
+			s.declareIn(body["body"].([]parser.Node), true, true)
+		}
+	case n.Is(parser.Program):
+		s.declareIn(n["body"].([]parser.Node), true, true)
+	case n.Is(parser.BlockStatement):
+		s.declareIn(n["body"].([]parser.Node), true, false)
+	case n.Is(parser.ForStatement):
+		if init, ok := n["init"].(parser.Node); ok && init != nil {
+			s.declareIn([]parser.Node{init}, true, false)
+		}
+	}
+}
+
+// declareIn binds the names declared by statements. Lexical declarations are
+// only bound when the statements are directly in the scope, while 'var' and
+// function declarations are found in nested statements of function scopes.
+func (s *Scope) declareIn(statements []parser.Node, direct bool, hoist bool) {
+	for _, n := range statements {
+		if n == nil {
+			continue
+		}
+
+		switch {
+		case n.Is(parser.VariableDeclaration):
+			if direct && n["kind"] != "var" || hoist && n["kind"] == "var" {
+				for _, declarator := range n["declarations"].([]parser.Node) {
+					s.bind(declarator["id"].(parser.Node))
+				}
+			}
+		case n.Is(parser.FunctionDeclaration, parser.ClassDeclaration):
+			if direct {
+				s.bind(n["id"].(parser.Node))
+			}
+		case hoist:
+			s.declareIn(nestedStatements(n), false, true)
+		}
+	}
+}
+
+// nestedStatements returns the statements nested in a compound statement.
+func nestedStatements(n parser.Node) []parser.Node {
+	keys := map[parser.Type][]string{
+		parser.BlockStatement:   {"body"},
+		parser.IfStatement:      {"consequent", "alternate"},
+		parser.WhileStatement:   {"body"},
+		parser.DoWhileStatement: {"body"},
+		parser.ForStatement:     {"init", "body"},
+	}[n.Type()]
+
+	statements := []parser.Node{}
+	for _, key := range keys {
+		switch child := n[key].(type) {
+		case parser.Node:
+			statements = append(statements, child)
+		case []parser.Node:
+			statements = append(statements, child...)
+		}
+	}
+
+	return statements
+}
+
+func (s *Scope) bind(id parser.Node) {
+	if id != nil && id.Is(parser.Identifier) {
+		s.Bindings[parser.IdentifierNode(id).Name()] = id
+	}
+}
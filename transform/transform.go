@@ -0,0 +1,270 @@
+// Package transform rewrites the trees produced by the parser. Traverse
+// visits every node with a NodePath, which knows where the node is in the
+// tree and can replace, remove or insert nodes next to it:
+//
+//	program = transform.Traverse(program, transform.ByType{
+//		parser.ExpressionStatement: {OnEnter: func(p *transform.NodePath) {
+//			if isConsoleLog(p.Node) {
+//				p.Remove()
+//			}
+//		}},
+//	})
+package transform
+
+import (
+	"fmt"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+// Visitor is called by Traverse when entering a node, before its children,
+// and when leaving it, after its children.
+type Visitor interface {
+	Enter(p *NodePath)
+	Leave(p *NodePath)
+}
+
+// Callbacks is a Visitor made of functions. A nil function does nothing.
+type Callbacks struct {
+	OnEnter func(p *NodePath)
+	OnLeave func(p *NodePath)
+}
+
+func (c Callbacks) Enter(p *NodePath) {
+	if c.OnEnter != nil {
+		c.OnEnter(p)
+	}
+}
+
+func (c Callbacks) Leave(p *NodePath) {
+	if c.OnLeave != nil {
+		c.OnLeave(p)
+	}
+}
+
+// ByType is a Visitor calling the Callbacks of the type of each node.
+type ByType map[parser.Type]Callbacks
+
+func (b ByType) Enter(p *NodePath) {
+	b[p.Node.Type()].Enter(p)
+}
+
+func (b ByType) Leave(p *NodePath) {
+	b[p.Node.Type()].Leave(p)
+}
+
+// NodePath is the position of a node in the tree being traversed.
+type NodePath struct {
+	Node parser.Node
+	// Parent is the path of the parent node, nil for the root.
+	Parent *NodePath
+	// Key is the key of the node in its parent.
+	Key string
+	// Index is the index of the node in the list at Key, or -1 when the key
+	// holds a single node.
+	Index int
+
+	traversal *traversal
+	scope     *Scope
+	// inserted counts the nodes inserted after the node, which the
+	// traversal steps over.
+	inserted int
+	removed  bool
+	skipped  bool
+	// replaced counts replacements, so the traversal notices the node
+	// changing under it.
+	replaced int
+}
+
+type traversal struct {
+	visitor Visitor
+	stopped bool
+}
+
+// Traverse visits n and every node below it, in source order, and returns n.
+// Replacing a node walks the children of the replacement, while inserted
+// nodes are not visited.
+func Traverse(n parser.Node, v Visitor) parser.Node {
+	root := &NodePath{Node: n, Index: -1, traversal: &traversal{visitor: v}}
+	root.traversal.visit(root)
+
+	return root.Node
+}
+
+func (t *traversal) visit(p *NodePath) {
+	t.visitor.Enter(p)
+	if t.stopped || p.removed {
+		return
+	}
+
+	if !p.skipped {
+		t.children(p)
+		if t.stopped || p.removed {
+			return
+		}
+	}
+
+	t.visitor.Leave(p)
+}
+
+func (t *traversal) children(p *NodePath) {
+	replaced := p.replaced
+	changed := func() bool {
+		return t.stopped || p.removed || p.replaced != replaced
+	}
+
+	for _, key := range walk.KeysOf(p.Node) {
+		switch child := p.Node[key].(type) {
+		case parser.Node:
+			if child != nil {
+				t.visit(&NodePath{Node: child, Parent: p, Key: key, Index: -1, traversal: t})
+			}
+		case []parser.Node:
+			for i := 0; i < len(p.Node[key].([]parser.Node)); {
+				path := &NodePath{Node: p.Node[key].([]parser.Node)[i], Parent: p, Key: key, Index: i, traversal: t}
+				if path.Node != nil {
+					t.visit(path)
+				}
+				if changed() {
+					return
+				}
+
+				i = path.Index + path.inserted
+				if !path.removed {
+					i++
+				}
+			}
+		}
+
+		if changed() {
+			return
+		}
+	}
+}
+
+// Skip doesn't visit the children of the node. The node is still left.
+func (p *NodePath) Skip() {
+	p.skipped = true
+}
+
+// Stop ends the traversal.
+func (p *NodePath) Stop() {
+	p.traversal.stopped = true
+}
+
+// Removed reports whether the node was removed.
+func (p *NodePath) Removed() bool {
+	return p.removed
+}
+
+// InList reports whether the node is in a list, such as a 'body'.
+func (p *NodePath) InList() bool {
+	return p.Index >= 0
+}
+
+// Replace puts n in place of the node.
+func (p *NodePath) Replace(n parser.Node) error {
+	if err := p.check(); err != nil {
+		return err
+	}
+
+	if p.InList() {
+		list := p.list()
+		list[p.Index] = n
+		p.setList(list)
+	} else {
+		p.Parent.Node[p.Key] = n
+	}
+
+	p.Node = n
+	p.replaced++
+
+	return nil
+}
+
+// Remove removes the node from its list, or sets the key holding it to
+// null. Its children are not visited and it is not left.
+func (p *NodePath) Remove() error {
+	if err := p.check(); err != nil {
+		return err
+	}
+
+	if p.InList() {
+		list := p.list()
+		p.setList(append(list[:p.Index], list[p.Index+1:]...))
+	} else {
+		p.Parent.Node[p.Key] = parser.Node(nil)
+	}
+
+	p.removed = true
+
+	return nil
+}
+
+// InsertBefore inserts nodes before the node in its list.
+func (p *NodePath) InsertBefore(nodes ...parser.Node) error {
+	if err := p.checkList(); err != nil {
+		return err
+	}
+
+	p.insert(p.Index, nodes)
+	p.Index += len(nodes)
+
+	return nil
+}
+
+// InsertAfter inserts nodes after the node in its list.
+func (p *NodePath) InsertAfter(nodes ...parser.Node) error {
+	if err := p.checkList(); err != nil {
+		return err
+	}
+
+	p.insert(p.Index+1+p.inserted, nodes)
+	p.inserted += len(nodes)
+
+	return nil
+}
+
+func (p *NodePath) insert(index int, nodes []parser.Node) {
+	list := p.list()
+
+	res := make([]parser.Node, 0, len(list)+len(nodes))
+	res = append(res, list[:index]...)
+	res = append(res, nodes...)
+	res = append(res, list[index:]...)
+
+	p.setList(res)
+}
+
+func (p *NodePath) check() error {
+	if p.Parent == nil {
+		return fmt.Errorf("cannot change the root %s", p.Node.Type())
+	}
+	if p.removed {
+		return fmt.Errorf("%s was removed", p.Node.Type())
+	}
+
+	return nil
+}
+
+func (p *NodePath) checkList() error {
+	if err := p.check(); err != nil {
+		return err
+	}
+	if !p.InList() {
+		return fmt.Errorf("%s.%s is not a list", p.Parent.Node.Type(), p.Key)
+	}
+
+	return nil
+}
+
+// list returns a copy of the list holding the node, so changing it doesn't
+// affect other references to the list.
+func (p *NodePath) list() []parser.Node {
+	return append([]parser.Node{}, p.Parent.Node[p.Key].([]parser.Node)...)
+}
+
+func (p *NodePath) setList(list []parser.Node) {
+	p.Parent.Node[p.Key] = list
+}
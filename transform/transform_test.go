@@ -0,0 +1,249 @@
+package transform_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/tokenizer"
+	"github.com/0xvesion/go-js-parser/transform"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+func parse(t *testing.T, src string) parser.Node {
+	n, err := parser.New(tokenizer.New(src)).Parse()
+	if err != nil {
+		t.Fatalf("%s: %v", src, err)
+	}
+
+	return n
+}
+
+// names lists the identifiers and literals of a tree, which is enough to
+// tell the tested trees apart.
+func names(n parser.Node) string {
+	res := []string{}
+	walk.Inspect(n, func(n parser.Node) bool {
+		switch {
+		case n.Is(parser.Identifier):
+			res = append(res, parser.IdentifierNode(n).Name())
+		case n.Is(parser.Literal):
+			res = append(res, parser.LiteralNode(n).Raw())
+		}
+
+		return true
+	})
+
+	return strings.Join(res, " ")
+}
+
+func TestReplace(t *testing.T) {
+	n := parse(t, `a = a + b;`)
+
+	transform.Traverse(n, transform.ByType{
+		parser.Identifier: {OnEnter: func(p *transform.NodePath) {
+			if parser.IdentifierNode(p.Node).Name() == "a" {
+				if err := p.Replace(parser.NewIdentifier(p.Node.Start(), p.Node.End(), "c")); err != nil {
+					t.Error(err)
+				}
+			}
+		}},
+		parser.BinaryExpression: {OnLeave: func(p *transform.NodePath) {
+			err := p.Replace(parser.NewCallExpression(p.Node.Start(), p.Node.End(), parser.NewIdentifier(0, 0, "add"), []parser.Node{
+				p.Node["left"].(parser.Node),
+				p.Node["right"].(parser.Node),
+			}))
+			if err != nil {
+				t.Error(err)
+			}
+		}},
+	})
+
+	if actual := names(n); actual != "c add c b" {
+		t.Errorf("Unexpected tree: %s", actual)
+	}
+}
+
+func TestListChanges(t *testing.T) {
+	n := parse(t, `1; 2; 3; 4; 5;`)
+	visited := []string{}
+
+	transform.Traverse(n, transform.ByType{
+		parser.Literal: {OnEnter: func(p *transform.NodePath) {
+			visited = append(visited, parser.LiteralNode(p.Node).Raw())
+		}},
+		parser.ExpressionStatement: {OnEnter: func(p *transform.NodePath) {
+			literal := func(raw string) parser.Node {
+				return parser.NewExpressionStatement(0, 0, parser.NewLiteral(0, 0, 0, raw))
+			}
+
+			var err error
+			switch parser.LiteralNode(p.Node["expression"].(parser.Node)).Raw() {
+			case "1":
+				err = p.InsertBefore(literal("0a"), literal("0b"))
+			case "2":
+				err = p.Remove()
+			case "3":
+				err = p.InsertAfter(literal("3a"))
+				if err == nil {
+					err = p.InsertAfter(literal("3b"))
+				}
+			case "4":
+				if err = p.InsertAfter(literal("4a")); err == nil {
+					err = p.Remove()
+				}
+			}
+			if err != nil {
+				t.Error(err)
+			}
+		}},
+	})
+
+	if actual := names(n); actual != "0a 0b 1 3 3a 3b 4a 5" {
+		t.Errorf("Unexpected tree: %s", actual)
+	}
+	if actual := strings.Join(visited, " "); actual != "1 3 5" {
+		t.Errorf("Unexpected visits: %s", actual)
+	}
+}
+
+func TestRemoveSingleChild(t *testing.T) {
+	n := parse(t, `if (a) b; else c;`)
+
+	transform.Traverse(n, transform.ByType{
+		parser.IfStatement: {OnLeave: func(p *transform.NodePath) {
+			if p.Node["alternate"].(parser.Node) != nil {
+				t.Error("Expected the alternate to be removed")
+			}
+		}},
+		parser.ExpressionStatement: {OnEnter: func(p *transform.NodePath) {
+			if p.Key == "alternate" {
+				if err := p.Remove(); err != nil {
+					t.Error(err)
+				}
+			}
+		}},
+	})
+
+	if actual := names(n); actual != "a b" {
+		t.Errorf("Unexpected tree: %s", actual)
+	}
+}
+
+func TestPathErrors(t *testing.T) {
+	n := parse(t, `a = b;`)
+
+	errs := []string{}
+	transform.Traverse(n, transform.Callbacks{OnEnter: func(p *transform.NodePath) {
+		var err error
+		switch {
+		case p.Node.Is(parser.Program):
+			if err = p.Remove(); err != nil {
+				errs = append(errs, err.Error())
+			}
+			err = p.Replace(parser.NewProgram(0, 0))
+		case p.Node.Is(parser.AssignmentExpression):
+			err = p.InsertBefore(parser.NewIdentifier(0, 0, "c"))
+		case p.Node.Is(parser.Identifier):
+			if err = p.Remove(); err == nil {
+				err = p.Replace(parser.NewIdentifier(0, 0, "c"))
+			}
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}})
+
+	expected := []string{
+		"cannot change the root Program",
+		"cannot change the root Program",
+		"ExpressionStatement.expression is not a list",
+		"Identifier was removed",
+		"Identifier was removed",
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+}
+
+func TestParentsAndStop(t *testing.T) {
+	n := parse(t, `a; { b; c; } d;`)
+
+	paths := []string{}
+	transform.Traverse(n, transform.ByType{
+		parser.Identifier: {OnEnter: func(p *transform.NodePath) {
+			chain := []string{}
+			for path := p; path.Parent != nil; path = path.Parent {
+				chain = append([]string{fmt.Sprintf("%s[%d]", path.Key, path.Index)}, chain...)
+			}
+			paths = append(paths, strings.Join(chain, "."))
+
+			if parser.IdentifierNode(p.Node).Name() == "c" {
+				p.Stop()
+			}
+		}},
+	})
+
+	expected := []string{"body[0].expression[-1]", "body[1].body[0].expression[-1]", "body[1].body[1].expression[-1]"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+}
+
+func TestScope(t *testing.T) {
+	n := parse(t, `let a; let b; function f(c) { let d; { let e; let g; if (x) { let h; } } for (let i = 0; i < 1; i += 1) j; class C {} }`)
+
+	// The parser doesn't support 'var' yet, but decoded trees may use it.
+	walk.Inspect(n, func(n parser.Node) bool {
+		if n.Is(parser.VariableDeclaration) {
+			switch parser.IdentifierNode(n["declarations"].([]parser.Node)[0]["id"].(parser.Node)).Name() {
+			case "g", "h":
+				n["kind"] = "var"
+			}
+		}
+
+		return true
+	})
+
+	scopes := map[string]*transform.Scope{}
+	transform.Traverse(n, transform.ByType{
+		parser.Identifier: {OnEnter: func(p *transform.NodePath) {
+			scopes[parser.IdentifierNode(p.Node).Name()] = p.Scope()
+		}},
+	})
+
+	bindings := func(s *transform.Scope) string {
+		names := []string{}
+		for name := range s.Bindings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return strings.Join(names, " ")
+	}
+
+	tests := []struct {
+		name     string
+		bindings string
+	}{
+		{"a", "a b f"},
+		{"c", "C c d g h"},
+		{"e", "e"},
+		{"i", "i"},
+	}
+	for _, test := range tests {
+		if actual := bindings(scopes[test.name]); actual != test.bindings {
+			t.Errorf("Unexpected bindings in the scope of %s: %s", test.name, actual)
+		}
+	}
+
+	if scopes["i"].Lookup("d") != scopes["c"] || scopes["i"].Lookup("a") != scopes["a"] {
+		t.Error("Unexpected scope chain")
+	}
+	if scopes["e"].HasBinding("window") {
+		t.Error("Expected globals to have no binding")
+	}
+}
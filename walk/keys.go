@@ -0,0 +1,107 @@
+package walk
+
+import "github.com/0xvesion/go-js-parser/parser"
+
+var (
+	functionKeys = []string{"id", "typeParameters", "params", "returnType", "body"}
+	classKeys    = []string{"decorators", "id", "typeParameters", "superClass", "superTypeArguments", "implements", "body"}
+	methodKeys   = []string{"decorators", "key", "value"}
+	propertyKeys = []string{"decorators", "key", "typeAnnotation", "value"}
+	binaryKeys   = []string{"left", "right"}
+)
+
+// Keys are the keys holding the children of every node type, in source
+// order. A key holds a Node or a []Node, and optional keys may be missing.
+var Keys = map[parser.Type][]string{
+	parser.Program:                       {"body"},
+	parser.Literal:                       {},
+	parser.ExpressionStatement:           {"expression"},
+	parser.BlockStatement:                {"body"},
+	parser.EmptyStatement:                {},
+	parser.BinaryExpression:              binaryKeys,
+	parser.AssignmentExpression:          binaryKeys,
+	parser.Identifier:                    {"typeAnnotation"},
+	parser.VariableDeclaration:           {"declarations"},
+	parser.VariableDeclarator:            {"id", "init"},
+	parser.IfStatement:                   {"test", "consequent", "alternate"},
+	parser.LogicalExpression:             binaryKeys,
+	parser.UnaryExpression:               {"argument"},
+	parser.WhileStatement:                {"test", "body"},
+	parser.ForStatement:                  {"init", "test", "update", "body"},
+	parser.DoWhileStatement:              {"body", "test"},
+	parser.FunctionDeclaration:           functionKeys,
+	parser.ReturnStatement:               {"argument"},
+	parser.MemberExpression:              {"object", "property"},
+	parser.CallExpression:                {"callee", "typeArguments", "arguments"},
+	parser.ClassDeclaration:              classKeys,
+	parser.ClassBody:                     {"body"},
+	parser.PropertyDefinition:            propertyKeys,
+	parser.MethodDefinition:              methodKeys,
+	parser.FunctionExpression:            functionKeys,
+	parser.SuperExpression:               {},
+	parser.ThisExpression:                {},
+	parser.ClassExpression:               classKeys,
+	parser.AccessorProperty:              propertyKeys,
+	parser.Decorator:                     {"expression"},
+	parser.ErrorNode:                     {},
+	parser.JSXElement:                    {"openingElement", "children", "closingElement"},
+	parser.JSXOpeningElement:             {"name", "attributes"},
+	parser.JSXClosingElement:             {"name"},
+	parser.JSXFragment:                   {"openingFragment", "children", "closingFragment"},
+	parser.JSXOpeningFragment:            {"attributes"},
+	parser.JSXClosingFragment:            {},
+	parser.JSXAttribute:                  {"name", "value"},
+	parser.JSXSpreadAttribute:            {"argument"},
+	parser.JSXExpressionContainer:        {"expression"},
+	parser.JSXEmptyExpression:            {},
+	parser.JSXSpreadChild:                {"expression"},
+	parser.JSXText:                       {},
+	parser.JSXIdentifier:                 {},
+	parser.JSXMemberExpression:           {"object", "property"},
+	parser.JSXNamespacedName:             {"namespace", "name"},
+	parser.TSTypeAnnotation:              {"typeAnnotation"},
+	parser.TSAnyKeyword:                  {},
+	parser.TSUnknownKeyword:              {},
+	parser.TSNumberKeyword:               {},
+	parser.TSObjectKeyword:               {},
+	parser.TSBooleanKeyword:              {},
+	parser.TSBigIntKeyword:               {},
+	parser.TSStringKeyword:               {},
+	parser.TSSymbolKeyword:               {},
+	parser.TSVoidKeyword:                 {},
+	parser.TSUndefinedKeyword:            {},
+	parser.TSNullKeyword:                 {},
+	parser.TSNeverKeyword:                {},
+	parser.TSThisType:                    {},
+	parser.TSTypeReference:               {"typeName", "typeArguments"},
+	parser.TSQualifiedName:               {"left", "right"},
+	parser.TSArrayType:                   {"elementType"},
+	parser.TSIndexedAccessType:           {"objectType", "indexType"},
+	parser.TSUnionType:                   {"types"},
+	parser.TSIntersectionType:            {"types"},
+	parser.TSTypeOperator:                {"typeAnnotation"},
+	parser.TSLiteralType:                 {"literal"},
+	parser.TSTupleType:                   {"elementTypes"},
+	parser.TSTypeLiteral:                 {"members"},
+	parser.TSPropertySignature:           {"key", "typeAnnotation"},
+	parser.TSMethodSignature:             {"key", "typeParameters", "params", "returnType"},
+	parser.TSIndexSignature:              {"parameters", "typeAnnotation"},
+	parser.TSFunctionType:                {"typeParameters", "params", "returnType"},
+	parser.TSTypeParameterDeclaration:    {"params"},
+	parser.TSTypeParameter:               {"name", "constraint", "default"},
+	parser.TSTypeParameterInstantiation:  {"params"},
+	parser.TSInterfaceDeclaration:        {"id", "typeParameters", "extends", "body"},
+	parser.TSInterfaceBody:               {"body"},
+	parser.TSInterfaceHeritage:           {"expression", "typeArguments"},
+	parser.TSTypeAliasDeclaration:        {"id", "typeParameters", "typeAnnotation"},
+	parser.TSEnumDeclaration:             {"id", "members"},
+	parser.TSEnumMember:                  {"id", "initializer"},
+	parser.TSAsExpression:                {"expression", "typeAnnotation"},
+	parser.TSSatisfiesExpression:         {"expression", "typeAnnotation"},
+	parser.TSNonNullExpression:           {"expression"},
+	parser.TSParameterProperty:           {"parameter"},
+	parser.TSClassImplements:             {"expression", "typeArguments"},
+	parser.TSAbstractMethodDefinition:    methodKeys,
+	parser.TSAbstractPropertyDefinition:  propertyKeys,
+	parser.TSEmptyBodyFunctionExpression: functionKeys,
+}
@@ -0,0 +1,143 @@
+// Package walk traverses the trees produced by the parser. Walk calls a
+// Visitor when entering and leaving every node, in source order:
+//
+//	walk.Walk(program, walk.ByType{
+//		parser.Identifier: {OnEnter: func(n, parent parser.Node) walk.Action {
+//			fmt.Println(n["name"])
+//			return walk.Continue
+//		}},
+//	})
+package walk
+
+import (
+	"sort"
+
+	"github.com/0xvesion/go-js-parser/parser"
+)
+
+// Action tells Walk how to go on after visiting a node.
+type Action int
+
+const (
+	// Continue visits the children of the node, then its siblings.
+	Continue Action = iota
+	// Skip doesn't visit the children of the node. The node is still left.
+	Skip
+	// Stop ends the walk.
+	Stop
+)
+
+// Visitor is called by Walk when entering a node, before its children, and
+// when leaving it, after its children. The parent of the root is nil.
+type Visitor interface {
+	Enter(n parser.Node, parent parser.Node) Action
+	Leave(n parser.Node, parent parser.Node) Action
+}
+
+// Callbacks is a Visitor made of functions. A nil function continues.
+type Callbacks struct {
+	OnEnter func(n parser.Node, parent parser.Node) Action
+	OnLeave func(n parser.Node, parent parser.Node) Action
+}
+
+func (c Callbacks) Enter(n parser.Node, parent parser.Node) Action {
+	if c.OnEnter == nil {
+		return Continue
+	}
+
+	return c.OnEnter(n, parent)
+}
+
+func (c Callbacks) Leave(n parser.Node, parent parser.Node) Action {
+	if c.OnLeave == nil {
+		return Continue
+	}
+
+	return c.OnLeave(n, parent)
+}
+
+// ByType is a Visitor calling the Callbacks of the type of each node. Nodes
+// of other types are walked through.
+type ByType map[parser.Type]Callbacks
+
+func (b ByType) Enter(n parser.Node, parent parser.Node) Action {
+	return b[n.Type()].Enter(n, parent)
+}
+
+func (b ByType) Leave(n parser.Node, parent parser.Node) Action {
+	return b[n.Type()].Leave(n, parent)
+}
+
+// Walk visits n and every node below it.
+func Walk(n parser.Node, v Visitor) {
+	walk(n, nil, v)
+}
+
+// Inspect calls f for n and every node below it, in source order. The
+// children of a node are skipped when f returns false.
+func Inspect(n parser.Node, f func(n parser.Node) bool) {
+	Walk(n, Callbacks{OnEnter: func(n parser.Node, _ parser.Node) Action {
+		if f(n) {
+			return Continue
+		}
+
+		return Skip
+	}})
+}
+
+// walk returns false once the walk is stopped.
+func walk(n parser.Node, parent parser.Node, v Visitor) bool {
+	switch v.Enter(n, parent) {
+	case Stop:
+		return false
+	case Continue:
+		for _, child := range Children(n) {
+			if !walk(child, n, v) {
+				return false
+			}
+		}
+	}
+
+	return v.Leave(n, parent) != Stop
+}
+
+// KeysOf returns the keys which may hold the children of a node, in source
+// order. The keys of node types missing from Keys are found by their values
+// and sorted.
+func KeysOf(n parser.Node) []string {
+	if keys, ok := Keys[n.Type()]; ok {
+		return keys
+	}
+
+	keys := []string{}
+	for key, value := range n {
+		switch value.(type) {
+		case parser.Node, []parser.Node:
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Children returns the children of a node, in source order.
+func Children(n parser.Node) []parser.Node {
+	children := []parser.Node{}
+	for _, key := range KeysOf(n) {
+		switch child := n[key].(type) {
+		case parser.Node:
+			if child != nil {
+				children = append(children, child)
+			}
+		case []parser.Node:
+			for _, c := range child {
+				if c != nil {
+					children = append(children, c)
+				}
+			}
+		}
+	}
+
+	return children
+}
@@ -0,0 +1,158 @@
+package walk_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/0xvesion/go-js-parser/internal/testsource"
+	"github.com/0xvesion/go-js-parser/parser"
+	"github.com/0xvesion/go-js-parser/walk"
+)
+
+func TestKeysCoverTypes(t *testing.T) {
+	for _, typ := range parser.Types {
+		if _, ok := walk.Keys[typ]; !ok {
+			t.Errorf("No keys for %s", typ)
+		}
+	}
+}
+
+func TestKeysCoverChildren(t *testing.T) {
+	for _, s := range testsource.Sources {
+		walk.Inspect(testsource.Parse(t, s.Src, s.Options), func(n parser.Node) bool {
+			keys := walk.Keys[n.Type()]
+
+			for key, value := range n {
+				switch value.(type) {
+				case parser.Node, []parser.Node:
+					if !contains(keys, key) {
+						t.Errorf("%s: '%s' is missing from the keys of %s", s.Src, key, n.Type())
+					}
+				}
+			}
+
+			children := walk.Children(n)
+			for i := 1; i < len(children); i++ {
+				if children[i].Start() < children[i-1].End() {
+					t.Errorf("%s: the children of %s are out of order", s.Src, n.Type())
+				}
+			}
+
+			return true
+		})
+	}
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func trace(n parser.Node, enter walk.Action) []string {
+	events := []string{}
+	walk.Walk(n, walk.Callbacks{
+		OnEnter: func(n parser.Node, parent parser.Node) walk.Action {
+			events = append(events, fmt.Sprintf("enter %s", n.Type()))
+			if n.Is(parser.BinaryExpression) {
+				return enter
+			}
+
+			return walk.Continue
+		},
+		OnLeave: func(n parser.Node, parent parser.Node) walk.Action {
+			events = append(events, fmt.Sprintf("leave %s", n.Type()))
+
+			return walk.Continue
+		},
+	})
+
+	return events
+}
+
+func TestWalk(t *testing.T) {
+	n := testsource.Parse(t, `a = b + 1; c;`, parser.Options{})
+
+	tests := []struct {
+		action   walk.Action
+		expected string
+	}{
+		{walk.Continue, "enter Program, enter ExpressionStatement, enter AssignmentExpression, enter Identifier, leave Identifier, " +
+			"enter BinaryExpression, enter Identifier, leave Identifier, enter Literal, leave Literal, leave BinaryExpression, " +
+			"leave AssignmentExpression, leave ExpressionStatement, enter ExpressionStatement, enter Identifier, leave Identifier, " +
+			"leave ExpressionStatement, leave Program"},
+		{walk.Skip, "enter Program, enter ExpressionStatement, enter AssignmentExpression, enter Identifier, leave Identifier, " +
+			"enter BinaryExpression, leave BinaryExpression, leave AssignmentExpression, leave ExpressionStatement, " +
+			"enter ExpressionStatement, enter Identifier, leave Identifier, leave ExpressionStatement, leave Program"},
+		{walk.Stop, "enter Program, enter ExpressionStatement, enter AssignmentExpression, enter Identifier, leave Identifier, " +
+			"enter BinaryExpression"},
+	}
+
+	for _, test := range tests {
+		if actual := strings.Join(trace(n, test.action), ", "); actual != test.expected {
+			t.Errorf("Unexpected walk.\nwant: %s\ngot: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestByType(t *testing.T) {
+	n := testsource.Parse(t, `a = b + c;`, parser.Options{})
+
+	names, parents := []string{}, []parser.Type{}
+	walk.Walk(n, walk.ByType{
+		parser.Identifier: {OnEnter: func(n parser.Node, parent parser.Node) walk.Action {
+			names = append(names, parser.IdentifierNode(n).Name())
+			parents = append(parents, parent.Type())
+
+			return walk.Continue
+		}},
+	})
+
+	if !reflect.DeepEqual(names, []string{"a", "b", "c"}) {
+		t.Errorf("Unexpected names: %v", names)
+	}
+	if !reflect.DeepEqual(parents, []parser.Type{parser.AssignmentExpression, parser.BinaryExpression, parser.BinaryExpression}) {
+		t.Errorf("Unexpected parents: %v", parents)
+	}
+}
+
+func TestNilChildren(t *testing.T) {
+	n := testsource.Parse(t, `if (a) b; for (;;) c;`, parser.Options{})
+	n["body"] = append(n["body"].([]parser.Node), nil)
+
+	types := []parser.Type{}
+	walk.Inspect(n, func(n parser.Node) bool {
+		types = append(types, n.Type())
+
+		return true
+	})
+
+	expected := []parser.Type{
+		parser.Program, parser.IfStatement, parser.Identifier, parser.ExpressionStatement, parser.Identifier,
+		parser.ForStatement, parser.ExpressionStatement, parser.Identifier,
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("Unexpected nodes.\nwant: %v\ngot: %v", expected, types)
+	}
+}
+
+func TestChildrenOfUnknownTypes(t *testing.T) {
+	n := parser.Node{
+		"type":     parser.Type("Custom"),
+		"b":        parser.NewIdentifier(2, 3, "b"),
+		"a":        []parser.Node{parser.NewIdentifier(0, 1, "a")},
+		"name":     "custom",
+		"optional": parser.Node(nil),
+	}
+
+	children := walk.Children(n)
+	if len(children) != 2 || parser.IdentifierNode(children[0]).Name() != "a" {
+		t.Errorf("Unexpected children: %v", children)
+	}
+}